@@ -16,12 +16,230 @@ limitations under the License.
 
 package trello
 
+import "encoding/json"
+
+// ActionType is the kind of change recorded by an Action, as reported in its
+// type field. These are also the action types delivered to webhooks.
+// https://developers.trello.com/advanced-reference/action
+type ActionType string
+
+const (
+	ActionCreateCard                   ActionType = "createCard"
+	ActionUpdateCard                   ActionType = "updateCard"
+	ActionDeleteCard                   ActionType = "deleteCard"
+	ActionCopyCard                     ActionType = "copyCard"
+	ActionCommentCard                  ActionType = "commentCard"
+	ActionMoveCardToBoard              ActionType = "moveCardToBoard"
+	ActionMoveCardFromBoard            ActionType = "moveCardFromBoard"
+	ActionAddMemberToCard              ActionType = "addMemberToCard"
+	ActionRemoveMemberFromCard         ActionType = "removeMemberFromCard"
+	ActionAddLabelToCard               ActionType = "addLabelToCard"
+	ActionRemoveLabelFromCard          ActionType = "removeLabelFromCard"
+	ActionAddAttachmentToCard          ActionType = "addAttachmentToCard"
+	ActionDeleteAttachmentFromCard     ActionType = "deleteAttachmentFromCard"
+	ActionAddChecklistToCard           ActionType = "addChecklistToCard"
+	ActionRemoveChecklistFromCard      ActionType = "removeChecklistFromCard"
+	ActionUpdateCheckItemStateOnCard   ActionType = "updateCheckItemStateOnCard"
+	ActionCreateList                   ActionType = "createList"
+	ActionUpdateList                   ActionType = "updateList"
+	ActionMoveListToBoard              ActionType = "moveListToBoard"
+	ActionMoveListFromBoard            ActionType = "moveListFromBoard"
+	ActionUpdateCheckItem              ActionType = "updateCheckItem"
+	ActionCreateCheckItem              ActionType = "createCheckItem"
+	ActionDeleteCheckItem              ActionType = "deleteCheckItem"
+	ActionUpdateChecklist              ActionType = "updateChecklist"
+	ActionCreateLabel                  ActionType = "createLabel"
+	ActionUpdateLabel                  ActionType = "updateLabel"
+	ActionDeleteLabel                  ActionType = "deleteLabel"
+	ActionCreateBoard                  ActionType = "createBoard"
+	ActionUpdateBoard                  ActionType = "updateBoard"
+	ActionAddMemberToBoard             ActionType = "addMemberToBoard"
+	ActionRemoveMemberFromBoard        ActionType = "removeMemberFromBoard"
+	ActionMakeAdminOfBoard             ActionType = "makeAdminOfBoard"
+	ActionMakeNormalMemberOfBoard      ActionType = "makeNormalMemberOfBoard"
+	ActionMakeObserverOfBoard          ActionType = "makeObserverOfBoard"
+	ActionAddToOrganizationBoard       ActionType = "addToOrganizationBoard"
+	ActionRemoveFromOrganizationBoard  ActionType = "removeFromOrganizationBoard"
+	ActionEnablePlugin                 ActionType = "enablePlugin"
+	ActionDisablePlugin                ActionType = "disablePlugin"
+	ActionEnablePowerUp                ActionType = "enablePowerUp"
+	ActionDisablePowerUp               ActionType = "disablePowerUp"
+	ActionCreateOrganization           ActionType = "createOrganization"
+	ActionUpdateOrganization           ActionType = "updateOrganization"
+	ActionAddMemberToOrganization      ActionType = "addMemberToOrganization"
+	ActionRemoveMemberFromOrganization ActionType = "removeMemberFromOrganization"
+	ActionVoteOnCard                   ActionType = "voteOnCard"
+	ActionEmailCard                    ActionType = "emailCard"
+	ActionConvertToCardFromCheckItem   ActionType = "convertToCardFromCheckItem"
+)
+
+// BoardRef, ListRef, CardRef and the other *Ref types are the abbreviated
+// objects Trello embeds in action data.
+type BoardRef struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	ShortLink string `json:"shortLink"`
+}
+
+type ListRef struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CardRef struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	ShortLink string `json:"shortLink"`
+	IdShort   int    `json:"idShort"`
+	IdList    string `json:"idList"`
+	Closed    bool   `json:"closed"`
+}
+
+type MemberRef struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+type ChecklistRef struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type CheckItemRef struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+type LabelRef struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type AttachmentRef struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+// CommentData is the data of a commentCard action.
+type CommentData struct {
+	Text  string   `json:"text"`
+	Board BoardRef `json:"board"`
+	List  ListRef  `json:"list"`
+	Card  CardRef  `json:"card"`
+}
+
+// CreateCardData is the data of a createCard action.
+type CreateCardData struct {
+	Board BoardRef `json:"board"`
+	List  ListRef  `json:"list"`
+	Card  CardRef  `json:"card"`
+}
+
+// DeleteCardData is the data of a deleteCard action.
+type DeleteCardData struct {
+	Board BoardRef `json:"board"`
+	List  ListRef  `json:"list"`
+	Card  CardRef  `json:"card"`
+}
+
+// CopyCardData is the data of a copyCard action.
+type CopyCardData struct {
+	Board      BoardRef `json:"board"`
+	List       ListRef  `json:"list"`
+	Card       CardRef  `json:"card"`
+	CardSource CardRef  `json:"cardSource"`
+}
+
+// MoveCardData is the data of an updateCard action which moved the card
+// between two lists of the same board.
+type MoveCardData struct {
+	Board      BoardRef `json:"board"`
+	ListBefore ListRef  `json:"listBefore"`
+	ListAfter  ListRef  `json:"listAfter"`
+	Card       CardRef  `json:"card"`
+}
+
+// UpdateCardData is the data of any other updateCard action. Old holds the
+// previous values of the changed fields.
+type UpdateCardData struct {
+	Board BoardRef               `json:"board"`
+	List  ListRef                `json:"list"`
+	Card  CardRef                `json:"card"`
+	Old   map[string]interface{} `json:"old"`
+}
+
+// MoveCardBoardData is the data of the moveCardToBoard and moveCardFromBoard
+// actions.
+type MoveCardBoardData struct {
+	Board       BoardRef `json:"board"`
+	BoardSource BoardRef `json:"boardSource"`
+	BoardTarget BoardRef `json:"boardTarget"`
+	List        ListRef  `json:"list"`
+	Card        CardRef  `json:"card"`
+}
+
+// CardMemberData is the data of the addMemberToCard and removeMemberFromCard
+// actions.
+type CardMemberData struct {
+	Board    BoardRef  `json:"board"`
+	Card     CardRef   `json:"card"`
+	IdMember string    `json:"idMember"`
+	Member   MemberRef `json:"member"`
+}
+
+// CardLabelData is the data of the addLabelToCard and removeLabelFromCard
+// actions.
+type CardLabelData struct {
+	Board BoardRef `json:"board"`
+	Card  CardRef  `json:"card"`
+	Label LabelRef `json:"label"`
+	Text  string   `json:"text"`
+}
+
+// CardAttachmentData is the data of the addAttachmentToCard and
+// deleteAttachmentFromCard actions.
+type CardAttachmentData struct {
+	Board      BoardRef      `json:"board"`
+	List       ListRef       `json:"list"`
+	Card       CardRef       `json:"card"`
+	Attachment AttachmentRef `json:"attachment"`
+}
+
+// CardChecklistData is the data of the addChecklistToCard and
+// removeChecklistFromCard actions.
+type CardChecklistData struct {
+	Board     BoardRef     `json:"board"`
+	Card      CardRef      `json:"card"`
+	Checklist ChecklistRef `json:"checklist"`
+}
+
+// CheckItemStateData is the data of an updateCheckItemStateOnCard action.
+type CheckItemStateData struct {
+	Board     BoardRef     `json:"board"`
+	Card      CardRef      `json:"card"`
+	Checklist ChecklistRef `json:"checklist"`
+	CheckItem CheckItemRef `json:"checkItem"`
+}
+
+// ListData is the data of the createList and updateList actions. Old holds
+// the previous values of the changed fields.
+type ListData struct {
+	Board BoardRef               `json:"board"`
+	List  ListRef                `json:"list"`
+	Old   map[string]interface{} `json:"old"`
+}
+
 type Action struct {
 	client          *Client
+	rawData         json.RawMessage
 	Id              string `json:"id"`
 	IdMemberCreator string `json:"idMemberCreator"`
 	Data            struct {
-		DateLastEdited string `json:"dateLastEdited"`
+		DateLastEdited Time `json:"dateLastEdited"`
 		ListBefore     struct {
 			Id   string `json:"id"`
 			Name string `json:"name"`
@@ -60,7 +278,7 @@ type Action struct {
 		Text string `json:"text"`
 	} `json:"data"`
 	Type          string `json:"type"`
-	Date          string `json:"date"`
+	Date          Time   `json:"date"`
 	MemberCreator struct {
 		Id         string `json:"id"`
 		AvatarHash string `json:"avatarHash"`
@@ -69,3 +287,72 @@ type Action struct {
 		Username   string `json:"username"`
 	} `json:"memberCreator"`
 }
+
+// UnmarshalJSON keeps a copy of the raw data field so that ParseData can decode
+// it again into the struct matching the action type.
+func (a *Action) UnmarshalJSON(b []byte) error {
+	type action Action
+	aux := struct {
+		*action
+		RawData json.RawMessage `json:"data"`
+	}{action: (*action)(a)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	a.rawData = aux.RawData
+	if len(aux.RawData) == 0 {
+		return nil
+	}
+	return json.Unmarshal(aux.RawData, &a.Data)
+}
+
+// ParseData will decode the data of the action into the struct matching its
+// type, e.g. *CommentData for a commentCard action. updateCard actions which
+// moved the card to another list decode into *MoveCardData. Actions of a type
+// without a dedicated struct decode into a map[string]interface{}.
+func (a *Action) ParseData() (interface{}, error) {
+	var data interface{}
+	switch ActionType(a.Type) {
+	case ActionCommentCard:
+		data = &CommentData{}
+	case ActionCreateCard:
+		data = &CreateCardData{}
+	case ActionDeleteCard:
+		data = &DeleteCardData{}
+	case ActionCopyCard:
+		data = &CopyCardData{}
+	case ActionUpdateCard:
+		if a.Data.ListAfter.Id != "" {
+			data = &MoveCardData{}
+		} else {
+			data = &UpdateCardData{}
+		}
+	case ActionMoveCardToBoard, ActionMoveCardFromBoard:
+		data = &MoveCardBoardData{}
+	case ActionAddMemberToCard, ActionRemoveMemberFromCard:
+		data = &CardMemberData{}
+	case ActionAddLabelToCard, ActionRemoveLabelFromCard:
+		data = &CardLabelData{}
+	case ActionAddAttachmentToCard, ActionDeleteAttachmentFromCard:
+		data = &CardAttachmentData{}
+	case ActionAddChecklistToCard, ActionRemoveChecklistFromCard:
+		data = &CardChecklistData{}
+	case ActionUpdateCheckItemStateOnCard:
+		data = &CheckItemStateData{}
+	case ActionCreateList, ActionUpdateList:
+		data = &ListData{}
+	default:
+		data = &map[string]interface{}{}
+	}
+
+	if len(a.rawData) > 0 {
+		if err := json.Unmarshal(a.rawData, data); err != nil {
+			return nil, err
+		}
+	}
+	if m, ok := data.(*map[string]interface{}); ok {
+		return *m, nil
+	}
+	return data, nil
+}
@@ -18,21 +18,47 @@ package trello
 
 type Attachment struct {
 	client    *Client
-	Id        string `json:"id"`
-	Bytes     int    `json:"bytes"`
-	Date      string `json:"date"`
-	EdgeColor string `json:"edgeColor"`
-	IdMember  string `json:"idMember"`
-	IsUpload  bool   `json:"isUpload"`
-	MimeType  string `json:"mimeType"`
-	Name      string `json:"name"`
-	Previews  []struct {
-		Width  int    `json:"width"`
-		Height int    `json:"height"`
-		Url    string `json:"url"`
-		Bytes  int    `json:"bytes"`
-		Id     string `json:"_id"`
-		Scaled bool   `json:"scaled"`
-	} `json:"previews"`
-	Url string `json:"url"`
+	Id        string              `json:"id"`
+	Bytes     int                 `json:"bytes"`
+	Date      Time                `json:"date"`
+	EdgeColor string              `json:"edgeColor"`
+	IdMember  string              `json:"idMember"`
+	IsUpload  bool                `json:"isUpload"`
+	MimeType  string              `json:"mimeType"`
+	Name      string              `json:"name"`
+	Previews  []AttachmentPreview `json:"previews"`
+	Url       string              `json:"url"`
+}
+
+// AttachmentPreview is a scaled variant of an image attachment
+type AttachmentPreview struct {
+	Id     string `json:"_id"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Url    string `json:"url"`
+	Bytes  int    `json:"bytes"`
+	Scaled bool   `json:"scaled"`
+}
+
+// Preview will return the smallest preview at least width by height pixels
+// large, or the largest preview when none is. It returns nil when the
+// attachment has no previews, e.g. when it is not an image.
+func (a *Attachment) Preview(width, height int) *AttachmentPreview {
+	var best *AttachmentPreview
+	for i := range a.Previews {
+		p := &a.Previews[i]
+		switch {
+		case best == nil:
+			best = p
+		case p.Width >= width && p.Height >= height:
+			if best.Width < width || best.Height < height || p.Width*p.Height < best.Width*best.Height {
+				best = p
+			}
+		case best.Width < width || best.Height < height:
+			if p.Width*p.Height > best.Width*best.Height {
+				best = p
+			}
+		}
+	}
+	return best
 }
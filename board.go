@@ -16,7 +16,15 @@ limitations under the License.
 
 package trello
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+)
 
 type Board struct {
 	client   *Client
@@ -31,6 +39,8 @@ type Board struct {
 	Pinned         bool   `json:"pinned"`
 	Url            string `json:"url"`
 	ShortUrl       string `json:"shortUrl"`
+	ShortLink      string `json:"shortLink"`
+	Subscribed     bool   `json:"subscribed"`
 	Prefs          struct {
 		PermissionLevel       string            `json:"permissionLevel"`
 		Voting                string            `json:"voting"`
@@ -61,19 +71,45 @@ type Board struct {
 	} `json:"labelNames"`
 }
 
+// BoardMyPrefs are the preferences of the acting member for a board
+// https://developers.trello.com/advanced-reference/board#get-1-boards-board-id-myprefs
+type BoardMyPrefs struct {
+	client                  *Client
+	boardID                 string // back pointer to the board Id
+	ShowSidebar             bool   `json:"showSidebar"`
+	ShowSidebarMembers      bool   `json:"showSidebarMembers"`
+	ShowSidebarBoardActions bool   `json:"showSidebarBoardActions"`
+	ShowSidebarActivity     bool   `json:"showSidebarActivity"`
+	ShowListGuide           bool   `json:"showListGuide"`
+	EmailPosition           string `json:"emailPosition"`
+	IdEmailList             string `json:"idEmailList"`
+	EmailKey                string `json:"emailKey"`
+}
+
+// BoardBackground is a scaled variant of a board background image
 type BoardBackground struct {
-	width  int    `json:"width"`
-	height int    `json:"height"`
-	url    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Url    string `json:"url"`
 }
 
+// BoardFilter restricts the boards returned by the board listing calls
+type BoardFilter string
+
+const (
+	BoardFilterAll          BoardFilter = "all"
+	BoardFilterOpen         BoardFilter = "open"
+	BoardFilterClosed       BoardFilter = "closed"
+	BoardFilterMembers      BoardFilter = "members"
+	BoardFilterOrganization BoardFilter = "organization"
+	BoardFilterPublic       BoardFilter = "public"
+	BoardFilterStarred      BoardFilter = "starred"
+)
+
 func (c *Client) Boards() (boards []Board, err error) {
-	body, err := c.Get("/boards/")
-	if err != nil {
+	if err = c.getJSON("/boards/", &boards); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &boards)
 	for i := range boards {
 		boards[i].client = c
 	}
@@ -81,23 +117,95 @@ func (c *Client) Boards() (boards []Board, err error) {
 }
 
 func (c *Client) Board(boardId string) (board *Board, err error) {
-	body, err := c.Get("/boards/" + boardId)
-	if err != nil {
+	if err = c.getJSON("/boards/"+boardId, &board); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &board)
 	board.client = c
 	return
 }
 
-func (b *Board) Lists() (lists []List, err error) {
-	body, err := b.client.Get("/boards/" + b.Id + "/lists")
+// CreateBoardOpts are the settings of a board created with Client.CreateBoard
+// or Client.CreateBoardFromTemplate
+type CreateBoardOpts struct {
+	Name           string
+	Desc           string
+	IdOrganization string
+	// PermissionLevel is one of private, org or public
+	PermissionLevel string
+	// DefaultLists and DefaultLabels control whether the board gets the
+	// To Do/Doing/Done lists and the six colored labels, which Trello adds
+	// when left nil. They are ignored when copying a board.
+	DefaultLists  *bool
+	DefaultLabels *bool
+	// KeepCards copies the cards along with the lists when copying a board
+	KeepCards bool
+}
+
+// CreateBoard will create a new board
+// https://developers.trello.com/advanced-reference/board#post-1-boards
+func (c *Client) CreateBoard(opts CreateBoardOpts) (*Board, error) {
+	return c.createBoard("", opts)
+}
+
+// CreateBoardFromTemplate will create a new board copying the lists, labels
+// and, with KeepCards, the cards of the board boardSourceId
+// https://developers.trello.com/advanced-reference/board#post-1-boards
+func (c *Client) CreateBoardFromTemplate(boardSourceId string, opts CreateBoardOpts) (*Board, error) {
+	return c.createBoard(boardSourceId, opts)
+}
+
+func (c *Client) createBoard(boardSourceId string, opts CreateBoardOpts) (*Board, error) {
+	if len(opts.Name) < 1 || len(opts.Name) > 16384 {
+		return nil, fmt.Errorf("Board name %q has invalid length. 1 <= length <= 16384", opts.Name)
+	}
+	payload := url.Values{}
+	payload.Set("name", opts.Name)
+	if opts.Desc != "" {
+		payload.Set("desc", opts.Desc)
+	}
+	if opts.IdOrganization != "" {
+		payload.Set("idOrganization", opts.IdOrganization)
+	}
+	if opts.PermissionLevel != "" {
+		allowed := boardPrefValues["permissionLevel"]
+		if !containsString(allowed, opts.PermissionLevel) {
+			return nil, fmt.Errorf("Board preference permissionLevel %q is invalid. Only %s", opts.PermissionLevel, strings.Join(allowed, ", "))
+		}
+		payload.Set("prefs_permissionLevel", opts.PermissionLevel)
+	}
+	if boardSourceId != "" {
+		payload.Set("idBoardSource", boardSourceId)
+		if opts.KeepCards {
+			payload.Set("keepFromSource", "cards")
+		} else {
+			payload.Set("keepFromSource", "none")
+		}
+	} else {
+		if opts.DefaultLists != nil {
+			payload.Set("defaultLists", strconv.FormatBool(*opts.DefaultLists))
+		}
+		if opts.DefaultLabels != nil {
+			payload.Set("defaultLabels", strconv.FormatBool(*opts.DefaultLabels))
+		}
+	}
+
+	body, err := c.Post("/boards", payload)
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	err = json.Unmarshal(body, &lists)
+	board := &Board{}
+	if err = json.Unmarshal(body, board); err != nil {
+		return nil, err
+	}
+	board.client = c
+	return board, nil
+}
+
+func (b *Board) Lists() (lists []List, err error) {
+	if err = b.client.getJSON("/boards/"+b.Id+"/lists", &lists); err != nil {
+		return
+	}
 	for i := range lists {
 		lists[i].client = b.client
 	}
@@ -105,12 +213,9 @@ func (b *Board) Lists() (lists []List, err error) {
 }
 
 func (b *Board) Members() (members []Member, err error) {
-	body, err := b.client.Get("/boards/" + b.Id + "/members?fields=all")
-	if err != nil {
+	if err = b.client.getJSON("/boards/"+b.Id+"/members?fields=all", &members); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &members)
 	for i := range members {
 		members[i].client = b.client
 	}
@@ -118,12 +223,9 @@ func (b *Board) Members() (members []Member, err error) {
 }
 
 func (b *Board) Cards() (cards []Card, err error) {
-	body, err := b.client.Get("/boards/" + b.Id + "/cards")
-	if err != nil {
+	if err = b.client.getJSON("/boards/"+b.Id+"/cards", &cards); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &cards)
 	for i := range cards {
 		cards[i].client = b.client
 	}
@@ -131,36 +233,34 @@ func (b *Board) Cards() (cards []Card, err error) {
 }
 
 func (b *Board) Card(IdCard string) (card *Card, err error) {
-	body, err := b.client.Get("/boards/" + b.Id + "/cards/" + IdCard)
-	if err != nil {
+	if err = b.client.getJSON("/boards/"+b.Id+"/cards/"+IdCard, &card); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &card)
 	card.client = b.client
 	return
 }
 
 func (b *Board) Checklists() (checklists []Checklist, err error) {
-	body, err := b.client.Get("/boards/" + b.Id + "/checklists")
-	if err != nil {
+	if err = b.client.getJSON("/boards/"+b.Id+"/checklists", &checklists); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &checklists)
 	for i := range checklists {
-		checklists[i].client = b.client
+		list := &checklists[i]
+		list.client = b.client
+		for i := range list.CheckItems {
+			item := &list.CheckItems[i]
+			item.client = b.client
+			item.listID = list.Id
+			item.cardID = list.IdCard
+		}
 	}
 	return
 }
 
 func (b *Board) MemberCards(IdMember string) (cards []Card, err error) {
-	body, err := b.client.Get("/boards/" + b.Id + "/members/" + IdMember + "/cards")
-	if err != nil {
+	if err = b.client.getJSON("/boards/"+b.Id+"/members/"+IdMember+"/cards", &cards); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &cards)
 	for i := range cards {
 		cards[i].client = b.client
 	}
@@ -173,25 +273,344 @@ func (b *Board) Actions(beforeId string) (actions []Action, err error) {
 		suffix = "?before=" + beforeId
 	}
 
-	body, err := b.client.Get("/boards/" + b.Id + "/actions" + suffix)
-	if err != nil {
+	if err = b.client.getJSON("/boards/"+b.Id+"/actions"+suffix, &actions); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &actions)
 	for i := range actions {
 		actions[i].client = b.client
 	}
 	return
 }
 
-func (b *Board) Organization() (organization Organization, err error) {
-	body, err := b.client.Get("/boards/" + b.Id + "/organization?fields=all")
+// Export will write to w the JSON export of the board, holding the board with
+// all its lists, cards, checklists, labels, members and its latest 1000
+// actions, as produced by the "Print and Export" menu of Trello
+// https://developers.trello.com/advanced-reference/board#get-1-boards-board-id
+func (b *Board) Export(w io.Writer) error {
+	return b.ExportContext(context.Background(), w)
+}
+
+// ExportContext is Export with a context cancelling the request
+func (b *Board) ExportContext(ctx context.Context, w io.Writer) error {
+	params := url.Values{}
+	params.Set("fields", "all")
+	params.Set("actions", "all")
+	params.Set("actions_limit", "1000")
+	params.Set("cards", "all")
+	params.Set("card_attachments", "true")
+	params.Set("checklists", "all")
+	params.Set("labels", "all")
+	params.Set("lists", "all")
+	params.Set("members", "all")
+	params.Set("member_fields", "all")
+
+	body, err := b.client.getStream(ctx, "/boards/"+b.Id+"?"+params.Encode())
 	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	_, err = io.Copy(w, body)
+	return err
+}
+
+// ActionsByType will return the actions of the board restricted to the given
+// types. As with Actions, a non-empty beforeId returns the page of actions
+// preceding that action.
+// https://developers.trello.com/advanced-reference/board#get-1-boards-board-id-actions
+func (b *Board) ActionsByType(beforeId string, types ...ActionType) (actions []Action, err error) {
+	params := url.Values{}
+	if len(types) > 0 {
+		params.Set("filter", actionFilter(types))
+	}
+	if beforeId != "" {
+		params.Set("before", beforeId)
+	}
+	return b.actions(params)
+}
+
+func (b *Board) actions(params url.Values) (actions []Action, err error) {
+	if err = b.client.getJSON("/boards/"+b.Id+"/actions?"+params.Encode(), &actions); err != nil {
 		return
 	}
+	for i := range actions {
+		actions[i].client = b.client
+	}
+	return
+}
 
-	err = json.Unmarshal(body, &organization)
+func actionFilter(types []ActionType) string {
+	filter := make([]string, len(types))
+	for i, t := range types {
+		filter[i] = string(t)
+	}
+	return strings.Join(filter, ",")
+}
+
+func (b *Board) Organization() (organization Organization, err error) {
+	if err = b.client.getJSON("/boards/"+b.Id+"/organization?fields=all", &organization); err != nil {
+		return
+	}
 	organization.client = b.client
 	return
 }
+
+// MyPrefs will return the preferences of the acting member for the board
+// https://developers.trello.com/advanced-reference/board#get-1-boards-board-id-myprefs
+func (b *Board) MyPrefs() (*BoardMyPrefs, error) {
+	prefs := &BoardMyPrefs{}
+	if err := b.client.getJSON("/boards/"+b.Id+"/myPrefs", prefs); err != nil {
+		return nil, err
+	}
+	prefs.client = b.client
+	prefs.boardID = b.Id
+	return prefs, nil
+}
+
+func (p *BoardMyPrefs) set(pref, value string) (*BoardMyPrefs, error) {
+	payload := url.Values{}
+	payload.Set("value", value)
+
+	body, err := p.client.Put("/boards/"+p.boardID+"/myPrefs/"+pref, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := &BoardMyPrefs{}
+	if err = json.Unmarshal(body, prefs); err != nil {
+		return nil, err
+	}
+	prefs.client = p.client
+	prefs.boardID = p.boardID
+	return prefs, nil
+}
+
+// SetShowSidebar will show or hide the board sidebar
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-myprefs-showsidebar
+func (p *BoardMyPrefs) SetShowSidebar(show bool) (*BoardMyPrefs, error) {
+	return p.set("showSidebar", strconv.FormatBool(show))
+}
+
+// SetShowSidebarMembers will show or hide the members in the board sidebar
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-myprefs-showsidebarmembers
+func (p *BoardMyPrefs) SetShowSidebarMembers(show bool) (*BoardMyPrefs, error) {
+	return p.set("showSidebarMembers", strconv.FormatBool(show))
+}
+
+// SetShowSidebarBoardActions will show or hide the board actions in the board sidebar
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-myprefs-showsidebarboardactions
+func (p *BoardMyPrefs) SetShowSidebarBoardActions(show bool) (*BoardMyPrefs, error) {
+	return p.set("showSidebarBoardActions", strconv.FormatBool(show))
+}
+
+// SetShowSidebarActivity will show or hide the activity in the board sidebar
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-myprefs-showsidebaractivity
+func (p *BoardMyPrefs) SetShowSidebarActivity(show bool) (*BoardMyPrefs, error) {
+	return p.set("showSidebarActivity", strconv.FormatBool(show))
+}
+
+// SetShowListGuide will show or hide the list guide
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-myprefs-showlistguide
+func (p *BoardMyPrefs) SetShowListGuide(show bool) (*BoardMyPrefs, error) {
+	return p.set("showListGuide", strconv.FormatBool(show))
+}
+
+// SetEmailPosition will set where cards emailed to the board are added, either
+// 'top' or 'bottom' of the list
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-myprefs-emailposition
+func (p *BoardMyPrefs) SetEmailPosition(position string) (*BoardMyPrefs, error) {
+	if position != "top" && position != "bottom" {
+		return nil, fmt.Errorf("Email position %q is invalid. Only 'top' or 'bottom'", position)
+	}
+	return p.set("emailPosition", position)
+}
+
+// SetIdEmailList will set the list that cards emailed to the board are added to
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-myprefs-idemaillist
+func (p *BoardMyPrefs) SetIdEmailList(listId string) (*BoardMyPrefs, error) {
+	return p.set("idEmailList", listId)
+}
+
+// BoardPrefsOpts holds the board preferences to change with SetPrefs. Empty
+// strings and nil pointers leave the matching preference untouched.
+type BoardPrefsOpts struct {
+	PermissionLevel string // private, org or public
+	Voting          string // disabled, members, observers, org or public
+	Comments        string // disabled, members, observers, org or public
+	Invitations     string // admins or members
+	SelfJoin        *bool
+	CardCovers      *bool
+	Background      string // a color name or the id of a custom background
+	CardAging       string // pirate or regular
+}
+
+var boardPrefValues = map[string][]string{
+	"permissionLevel": {"private", "org", "public"},
+	"voting":          {"disabled", "members", "observers", "org", "public"},
+	"comments":        {"disabled", "members", "observers", "org", "public"},
+	"invitations":     {"admins", "members"},
+	"cardAging":       {"pirate", "regular"},
+}
+
+// SetPrefs will update the board preferences set in opts, issuing one request
+// per preference. The board returned reflects all the changes.
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-prefs-permissionlevel
+func (b *Board) SetPrefs(opts BoardPrefsOpts) (*Board, error) {
+	type pref struct{ name, value string }
+	prefs := []pref{
+		{"permissionLevel", opts.PermissionLevel},
+		{"voting", opts.Voting},
+		{"comments", opts.Comments},
+		{"invitations", opts.Invitations},
+		{"background", opts.Background},
+		{"cardAging", opts.CardAging},
+	}
+	if opts.SelfJoin != nil {
+		prefs = append(prefs, pref{"selfJoin", strconv.FormatBool(*opts.SelfJoin)})
+	}
+	if opts.CardCovers != nil {
+		prefs = append(prefs, pref{"cardCovers", strconv.FormatBool(*opts.CardCovers)})
+	}
+
+	for _, p := range prefs {
+		if allowed, ok := boardPrefValues[p.name]; ok && p.value != "" && !containsString(allowed, p.value) {
+			return nil, fmt.Errorf("Board preference %s %q is invalid. Only %s", p.name, p.value, strings.Join(allowed, ", "))
+		}
+	}
+
+	newBoard := b
+	for _, p := range prefs {
+		if p.value == "" {
+			continue
+		}
+		payload := url.Values{}
+		payload.Set("value", p.value)
+
+		body, err := b.client.Put("/boards/"+b.Id+"/prefs/"+p.name, payload)
+		if err != nil {
+			return nil, err
+		}
+		newBoard = &Board{}
+		if err = json.Unmarshal(body, newBoard); err != nil {
+			return nil, err
+		}
+		newBoard.client = b.client
+	}
+	return newBoard, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// InviteMemberByEmail will invite someone to the board by email, whether or
+// not they already have a Trello account. role is one of admin, normal or observer.
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-members
+func (b *Board) InviteMemberByEmail(email, fullName, role string) error {
+	roles := []string{"admin", "normal", "observer"}
+	if !containsString(roles, role) {
+		return fmt.Errorf("Member role %q is invalid. Only %s", role, strings.Join(roles, ", "))
+	}
+	payload := url.Values{}
+	payload.Set("email", email)
+	payload.Set("fullName", fullName)
+	payload.Set("type", role)
+
+	_, err := b.client.Put("/boards/"+b.Id+"/members", payload)
+	return err
+}
+
+// Subscribe will make the member owning the client token watch the board, or
+// stop watching it
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-subscribed
+func (b *Board) Subscribe(subscribed bool) (*Board, error) {
+	payload := url.Values{}
+	payload.Set("value", strconv.FormatBool(subscribed))
+
+	body, err := b.client.Put("/boards/"+b.Id+"/subscribed", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	newBoard := &Board{}
+	if err = json.Unmarshal(body, newBoard); err != nil {
+		return nil, err
+	}
+	newBoard.client = b.client
+	return newBoard, nil
+}
+
+// SetBackground will set the background of the board to one of the colors
+// blue, orange, green, red, purple, pink, lime, sky or grey, or to a
+// background image by id, see Member.BoardBackgrounds
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-prefs-background
+func (b *Board) SetBackground(idOrColor string) (*Board, error) {
+	return b.SetPrefs(BoardPrefsOpts{Background: idOrColor})
+}
+
+// SetLabelName will set the name of the board label with the given color, one
+// of red, orange, yellow, green, blue or purple
+// https://developers.trello.com/advanced-reference/board#put-1-boards-board-id-labelnames-blue
+func (b *Board) SetLabelName(color, name string) (*Board, error) {
+	colors := []string{"red", "orange", "yellow", "green", "blue", "purple"}
+	if !containsString(colors, color) {
+		return nil, fmt.Errorf("Label color %q is invalid. Only %s", color, strings.Join(colors, ", "))
+	}
+	payload := url.Values{}
+	payload.Set("value", name)
+
+	body, err := b.client.Put("/boards/"+b.Id+"/labelNames/"+color, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	newBoard := &Board{}
+	if err = json.Unmarshal(body, newBoard); err != nil {
+		return nil, err
+	}
+	newBoard.client = b.client
+	return newBoard, nil
+}
+
+// BoardPlugin is a Power-Up enabled on a board
+// https://developers.trello.com/advanced-reference/board#get-1-boards-board-id-boardplugins
+type BoardPlugin struct {
+	Id       string `json:"id"`
+	IdBoard  string `json:"idBoard"`
+	IdPlugin string `json:"idPlugin"`
+}
+
+func (b *Board) BoardPlugins() (plugins []BoardPlugin, err error) {
+	err = b.client.getJSON("/boards/"+b.Id+"/boardPlugins", &plugins)
+	return
+}
+
+// EnablePlugin will enable the Power-Up on the board
+// https://developers.trello.com/advanced-reference/board#post-1-boards-board-id-boardplugins
+func (b *Board) EnablePlugin(pluginId string) (*BoardPlugin, error) {
+	payload := url.Values{}
+	payload.Set("idPlugin", pluginId)
+
+	body, err := b.client.Post("/boards/"+b.Id+"/boardPlugins", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	plugin := &BoardPlugin{}
+	if err = json.Unmarshal(body, plugin); err != nil {
+		return nil, err
+	}
+	return plugin, nil
+}
+
+// DisablePlugin will disable the Power-Up on the board
+// https://developers.trello.com/advanced-reference/board#delete-1-boards-board-id-boardplugins-idplugin
+func (b *Board) DisablePlugin(pluginId string) error {
+	_, err := b.client.Delete("/boards/" + b.Id + "/boardPlugins/" + pluginId)
+	return err
+}
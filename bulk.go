@@ -0,0 +1,73 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"sync"
+)
+
+// BulkOp is one operation run by Bulk, typically a closure calling the API,
+// e.g. creating a card.
+type BulkOp func() (interface{}, error)
+
+// BulkResult is the outcome of a BulkOp
+type BulkResult struct {
+	Value interface{}
+	Err   error
+}
+
+// Bulk will run ops with at most workers of them in flight at once and return
+// their results in the same order as ops. The requests still go through the
+// rate limiter of their client, so a large import only waits as needed.
+// Operations not started when ctx is done get ctx.Err() as their error.
+func Bulk(ctx context.Context, workers int, ops []BulkOp) []BulkResult {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]BulkResult, len(ops))
+	indexes := make(chan int)
+
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				value, err := ops[i]()
+				results[i] = BulkResult{Value: value, Err: err}
+			}
+		}()
+	}
+
+	next := 0
+feed:
+	for ; next < len(ops); next++ {
+		select {
+		case indexes <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for ; next < len(ops); next++ {
+		results[next].Err = ctx.Err()
+	}
+	return results
+}
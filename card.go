@@ -18,8 +18,11 @@ package trello
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 type Card struct {
@@ -32,17 +35,20 @@ type Card struct {
 	IdCheckLists          []string `json:"idCheckLists"`
 	IdBoard               string   `json:"idBoard"`
 	IdList                string   `json:"idList"`
+	IdLabels              []string `json:"idLabels"`
 	IdMembers             []string `json:"idMembers"`
 	IdMembersVoted        []string `json:"idMembersVoted"`
 	ManualCoverAttachment bool     `json:"manualCoverAttachment"`
 	Closed                bool     `json:"closed"`
-	Pos                   float32  `json:"pos"`
+	Pos                   float64  `json:"pos"`
 	ShortLink             string   `json:"shortLink"`
-	DateLastActivity      string   `json:"dateLastActivity"`
+	DateLastActivity      Time     `json:"dateLastActivity"`
 	ShortUrl              string   `json:"shortUrl"`
 	Subscribed            bool     `json:"subscribed"`
 	Url                   string   `json:"url"`
-	Due                   string   `json:"due"`
+	Due                   Time     `json:"due"`
+	Start                 Time     `json:"start"`
+	DueComplete           bool     `json:"dueComplete"`
 	Desc                  string   `json:"desc"`
 	DescData              struct {
 		Emoji struct{} `json:"emoji"`
@@ -51,43 +57,104 @@ type Card struct {
 		IdCheckItem string `json:"idCheckItem"`
 		State       string `json:"state"`
 	} `json:"checkItemStates"`
-	Badges struct {
-		Votes              int    `json:"votes"`
-		ViewingMemberVoted bool   `json:"viewingMemberVoted"`
-		Subscribed         bool   `json:"subscribed"`
-		Fogbugz            string `json:"fogbugz"`
-		CheckItems         int    `json:"checkItems"`
-		CheckItemsChecked  int    `json:"checkItemsChecked"`
-		Comments           int    `json:"comments"`
-		Attachments        int    `json:"attachments"`
-		Description        bool   `json:"description"`
-		Due                string `json:"due"`
-	} `json:"badges"`
-	Labels []struct {
-		Color string `json:"color"`
-		Name  string `json:"name"`
-		Id    string `json:"id"`
-	} `json:"labels"`
+	Badges Badges    `json:"badges"`
+	Labels []Label   `json:"labels"`
+	Cover  CardCover `json:"cover"`
+	// Address, LocationName and Coordinates are set by the Map Power-Up
+	Address      string       `json:"address"`
+	LocationName string       `json:"locationName"`
+	Coordinates  *Coordinates `json:"coordinates"`
+	// CustomFieldItems is only returned by the calls asking for it
+	CustomFieldItems []CustomFieldItem `json:"customFieldItems"`
+}
+
+// Coordinates is the location of a card on the map
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// UnmarshalJSON accepts both the object form and the "latitude,longitude"
+// string form Trello uses for coordinates. An empty string, which Trello sends
+// for cards without a location, leaves the coordinates zero.
+func (c *Coordinates) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		type coordinates Coordinates
+		return json.Unmarshal(b, (*coordinates)(c))
+	}
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return fmt.Errorf("Coordinates %q are invalid", s)
+	}
+	var err error
+	if c.Latitude, err = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64); err != nil {
+		return err
+	}
+	c.Longitude, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	return err
+}
+
+func (c Coordinates) String() string {
+	return strconv.FormatFloat(c.Latitude, 'f', -1, 64) + "," + strconv.FormatFloat(c.Longitude, 'f', -1, 64)
+}
+
+// CardCover is the image or color shown on the front of a card
+type CardCover struct {
+	IdAttachment         string              `json:"idAttachment"`
+	IdUploadedBackground string              `json:"idUploadedBackground"`
+	IdPlugin             string              `json:"idPlugin"`
+	Color                string              `json:"color"`
+	Brightness           string              `json:"brightness"` // light or dark
+	Size                 string              `json:"size"`       // normal or full
+	EdgeColor            string              `json:"edgeColor"`
+	SharedSourceUrl      string              `json:"sharedSourceUrl"`
+	Scaled               []AttachmentPreview `json:"scaled"`
+}
+
+// CoverOpts selects the cover set with Card.SetCover, which is one of a
+// color, an Unsplash image URL, an image attachment of the card or an
+// uploaded background.
+type CoverOpts struct {
+	Color                string // pink, yellow, lime, blue, black, orange, red, purple, sky or green
+	Url                  string // https://images.unsplash.com/... image URL
+	IdAttachment         string
+	IdUploadedBackground string
+	Brightness           string // light or dark, for the text over the cover
+	Size                 string // normal or full
+}
+
+// Badges are the counters Trello shows on the front of a card
+// https://developers.trello.com/advanced-reference/card
+type Badges struct {
+	Votes              int    `json:"votes"`
+	ViewingMemberVoted bool   `json:"viewingMemberVoted"`
+	Subscribed         bool   `json:"subscribed"`
+	Fogbugz            string `json:"fogbugz"`
+	CheckItems         int    `json:"checkItems"`
+	CheckItemsChecked  int    `json:"checkItemsChecked"`
+	Comments           int    `json:"comments"`
+	Attachments        int    `json:"attachments"`
+	Description        bool   `json:"description"`
+	Due                Time   `json:"due"`
+	DueComplete        bool   `json:"dueComplete"`
 }
 
 func (c *Client) Card(CardId string) (card *Card, err error) {
-	body, err := c.Get("/card/" + CardId)
-	if err != nil {
+	if err = c.getJSON("/card/"+CardId, &card); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &card)
 	card.client = c
 	return
 }
 
 func (c *Card) Checklists() (checklists []Checklist, err error) {
-	body, err := c.client.Get("/card/" + c.Id + "/checklists")
-	if err != nil {
+	if err = c.client.getJSON("/card/"+c.Id+"/checklists", &checklists); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &checklists)
 	for i := range checklists {
 		list := &checklists[i]
 		list.client = c.client
@@ -95,18 +162,16 @@ func (c *Card) Checklists() (checklists []Checklist, err error) {
 			item := &list.CheckItems[i]
 			item.client = c.client
 			item.listID = list.Id
+			item.cardID = c.Id
 		}
 	}
 	return
 }
 
 func (c *Card) Members() (members []Member, err error) {
-	body, err := c.client.Get("/cards/" + c.Id + "/members")
-	if err != nil {
+	if err = c.client.getJSON("/cards/"+c.Id+"/members", &members); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &members)
 	for i := range members {
 		members[i].client = c.client
 	}
@@ -114,30 +179,78 @@ func (c *Card) Members() (members []Member, err error) {
 }
 
 func (c *Card) Attachments() (attachments []Attachment, err error) {
-	body, err := c.client.Get("/cards/" + c.Id + "/attachments")
-	if err != nil {
+	if err = c.client.getJSON("/cards/"+c.Id+"/attachments", &attachments); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &attachments)
 	for i := range attachments {
 		attachments[i].client = c.client
 	}
 	return
 }
 
-// Attachment will return the specified attachment on the card
+// Attachment will return the specified attachment on the card, with only the
+// given fields set, e.g. "url" and "date". No fields returns all the fields.
 // https://developers.trello.com/advanced-reference/card#get-1-cards-card-id-or-shortlink-attachments-idattachment
-func (c *Card) Attachment(attachmentId string) (*Attachment, error) {
-	body, err := c.client.Get("/cards/" + c.Id + "/attachments/" + attachmentId)
+func (c *Card) Attachment(attachmentId string, field ...string) (*Attachment, error) {
+	resource := "/cards/" + c.Id + "/attachments/" + attachmentId
+	if len(field) > 0 {
+		resource += "?" + url.Values{"fields": {strings.Join(field, ",")}}.Encode()
+	}
+
+	attachment := &Attachment{}
+	if err := c.client.getJSON(resource, attachment); err != nil {
+		return nil, err
+	}
+	attachment.client = c.client
+	return attachment, nil
+}
+
+// AddAttachment will upload the content of r to the card as a file named name
+// https://developers.trello.com/advanced-reference/card#post-1-cards-card-id-or-shortlink-attachments
+func (c *Card) AddAttachment(name string, r io.Reader) (*Attachment, error) {
+	payload := url.Values{}
+	payload.Set("name", name)
+
+	body, err := c.client.PostFile("/cards/"+c.Id+"/attachments", payload, "file", name, r)
 	if err != nil {
 		return nil, err
 	}
+	return c.decodeAttachment(body)
+}
+
+// AddURLAttachment will attach a link to the card. name defaults to the URL
+// when empty.
+// https://developers.trello.com/advanced-reference/card#post-1-cards-card-id-or-shortlink-attachments
+func (c *Card) AddURLAttachment(attachmentUrl, name string) (*Attachment, error) {
+	payload := url.Values{}
+	payload.Set("url", attachmentUrl)
+	if name != "" {
+		payload.Set("name", name)
+	}
+
+	body, err := c.client.Post("/cards/"+c.Id+"/attachments", payload)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeAttachment(body)
+}
 
+func (c *Card) decodeAttachment(body []byte) (*Attachment, error) {
 	attachment := &Attachment{}
-	err = json.Unmarshal(body, attachment)
+	if err := json.Unmarshal(body, attachment); err != nil {
+		return nil, err
+	}
 	attachment.client = c.client
-	return attachment, err
+	return attachment, nil
+}
+
+// DeleteAttachment will remove the attachment from the card. Trello has no
+// call to update an attachment, so replacing one means deleting it and adding
+// the new file or link with AddAttachment or AddURLAttachment.
+// https://developers.trello.com/advanced-reference/card#delete-1-cards-card-id-or-shortlink-attachments-idattachment
+func (c *Card) DeleteAttachment(attachmentId string) error {
+	_, err := c.client.Delete("/cards/" + c.Id + "/attachments/" + attachmentId)
+	return err
 }
 
 func (c *Card) Actions(beforeId string) (actions []Action, err error) {
@@ -146,12 +259,9 @@ func (c *Card) Actions(beforeId string) (actions []Action, err error) {
 		suffix = "?filter=all&before=" + beforeId
 	}
 
-	body, err := c.client.Get("/cards/" + c.Id + "/actions" + suffix)
-	if err != nil {
+	if err = c.client.getJSON("/cards/"+c.Id+"/actions"+suffix, &actions); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &actions)
 	for i := range actions {
 		actions[i].client = c.client
 	}
@@ -177,6 +287,44 @@ func (c *Card) AddChecklist(name string) (*Checklist, error) {
 	return newList, err
 }
 
+// CopyChecklist will add a copy of an existing checklist, with its items, to
+// the card. An empty name keeps the name of the source checklist and a nil pos
+// adds the checklist at the bottom, otherwise pos can take the values "top",
+// "bottom" or a positive number.
+// https://developers.trello.com/advanced-reference/card#post-1-cards-card-id-or-shortlink-checklists
+func (c *Card) CopyChecklist(checklistSourceId, name string, pos interface{}) (*Checklist, error) {
+	payload := url.Values{}
+	payload.Set("idChecklistSource", checklistSourceId)
+	if name != "" {
+		payload.Set("name", name)
+	}
+	if pos != nil {
+		position, err := formatPosition(pos)
+		if err != nil {
+			return nil, err
+		}
+		payload.Set("pos", position)
+	}
+
+	body, err := c.client.Post("/cards/"+c.Id+"/checklists", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	newList := &Checklist{}
+	if err = json.Unmarshal(body, newList); err != nil {
+		return nil, err
+	}
+	newList.client = c.client
+	for i := range newList.CheckItems {
+		item := &newList.CheckItems[i]
+		item.client = c.client
+		item.listID = newList.Id
+		item.cardID = c.Id
+	}
+	return newList, nil
+}
+
 // AddComment will add a new comment to the card
 // https://developers.trello.com/advanced-reference/card#post-1-cards-card-id-or-shortlink-actions-comments
 func (c *Card) AddComment(text string) (*Action, error) {
@@ -268,3 +416,226 @@ func (c *Card) MoveToPos(pos int) (*Card, error) {
 	newCard.client = c.client
 	return newCard, nil
 }
+
+// SetPosition will move the card within its list. pos can take the values
+// "top", "bottom" or a positive number, see PositionBetween.
+// https://developers.trello.com/advanced-reference/card#put-1-cards-card-id-or-shortlink-pos
+func (c *Card) SetPosition(pos interface{}) (*Card, error) {
+	position, err := formatPosition(pos)
+	if err != nil {
+		return nil, err
+	}
+	payload := url.Values{}
+	payload.Set("value", position)
+
+	return c.put("/pos", payload)
+}
+
+// MoveToBoard will move the card to a list of another board. When listId is
+// empty the card goes to the first open list of the board.
+// Trello copies the labels of the card onto the target board, reusing labels
+// with the same name and color, and drops the members which are not members
+// of the target board, so Labels and IdMembers of the returned card may
+// differ from the original ones.
+// https://developers.trello.com/advanced-reference/card#put-1-cards-card-id-or-shortlink
+func (c *Card) MoveToBoard(boardId, listId string) (*Card, error) {
+	payload := url.Values{}
+	payload.Set("idBoard", boardId)
+	if listId != "" {
+		payload.Set("idList", listId)
+	}
+
+	return c.put("", payload)
+}
+
+// SetDueComplete will mark the due date of the card as complete, or not
+// https://developers.trello.com/advanced-reference/card#put-1-cards-card-id-or-shortlink
+func (c *Card) SetDueComplete(complete bool) (*Card, error) {
+	payload := url.Values{}
+	payload.Set("value", strconv.FormatBool(complete))
+
+	return c.put("/dueComplete", payload)
+}
+
+// Subscribe will subscribe the member owning the client token to the card
+// https://developers.trello.com/advanced-reference/card#put-1-cards-card-id-or-shortlink-subscribed
+func (c *Card) Subscribe() (*Card, error) {
+	payload := url.Values{}
+	payload.Set("value", "true")
+
+	return c.put("/subscribed", payload)
+}
+
+// Unsubscribe will unsubscribe the member owning the client token from the card
+// https://developers.trello.com/advanced-reference/card#put-1-cards-card-id-or-shortlink-subscribed
+func (c *Card) Unsubscribe() (*Card, error) {
+	payload := url.Values{}
+	payload.Set("value", "false")
+
+	return c.put("/subscribed", payload)
+}
+
+// AddLabel will put the board label on the card
+// https://developers.trello.com/advanced-reference/card#post-1-cards-card-id-or-shortlink-idlabels
+func (c *Card) AddLabel(labelId string) error {
+	payload := url.Values{}
+	payload.Set("value", labelId)
+
+	_, err := c.client.Post("/cards/"+c.Id+"/idLabels", payload)
+	return err
+}
+
+// RemoveLabel will take the label off the card, the label stays on the board
+// https://developers.trello.com/advanced-reference/card#delete-1-cards-card-id-or-shortlink-idlabels-idlabel
+func (c *Card) RemoveLabel(labelId string) error {
+	_, err := c.client.Delete("/cards/" + c.Id + "/idLabels/" + labelId)
+	return err
+}
+
+// CreateAndAddLabel will create a new label on the board of the card and put
+// it on the card. color takes the same values as for Board.CreateLabel.
+// https://developers.trello.com/advanced-reference/card#post-1-cards-card-id-or-shortlink-labels
+func (c *Card) CreateAndAddLabel(name, color string) (*Label, error) {
+	colorValue, err := labelColorValue(color)
+	if err != nil {
+		return nil, err
+	}
+	payload := url.Values{}
+	payload.Set("name", name)
+	payload.Set("color", colorValue)
+
+	body, err := c.client.Post("/cards/"+c.Id+"/labels", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	label := &Label{}
+	if err = json.Unmarshal(body, label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+// MembersVoted will return the members who voted for the card
+// https://developers.trello.com/advanced-reference/card#get-1-cards-card-id-or-shortlink-membersvoted
+func (c *Card) MembersVoted() (members []Member, err error) {
+	if err = c.client.getJSON("/cards/"+c.Id+"/membersVoted", &members); err != nil {
+		return
+	}
+	for i := range members {
+		members[i].client = c.client
+	}
+	return
+}
+
+// Vote will vote for the card on behalf of the member, which must be the
+// member owning the client token
+// https://developers.trello.com/advanced-reference/card#post-1-cards-card-id-or-shortlink-membersvoted
+func (c *Card) Vote(memberId string) error {
+	payload := url.Values{}
+	payload.Set("value", memberId)
+
+	_, err := c.client.Post("/cards/"+c.Id+"/membersVoted", payload)
+	return err
+}
+
+// RemoveVote will remove the vote of the member for the card
+// https://developers.trello.com/advanced-reference/card#delete-1-cards-card-id-or-shortlink-membersvoted-idmember
+func (c *Card) RemoveVote(memberId string) error {
+	_, err := c.client.Delete("/cards/" + c.Id + "/membersVoted/" + memberId)
+	return err
+}
+
+// SetCover will set the cover of the card
+// https://developers.trello.com/reference#cards-2
+func (c *Card) SetCover(opts CoverOpts) (*Card, error) {
+	cover := map[string]string{}
+	for key, value := range map[string]string{
+		"color":                opts.Color,
+		"url":                  opts.Url,
+		"idAttachment":         opts.IdAttachment,
+		"idUploadedBackground": opts.IdUploadedBackground,
+	} {
+		if value != "" {
+			cover[key] = value
+		}
+	}
+	if len(cover) != 1 {
+		return nil, fmt.Errorf("Card cover needs exactly one of color, url, idAttachment or idUploadedBackground")
+	}
+	colors := []string{"pink", "yellow", "lime", "blue", "black", "orange", "red", "purple", "sky", "green"}
+	if opts.Color != "" && !containsString(colors, opts.Color) {
+		return nil, fmt.Errorf("Card cover color %q is invalid. Only %s", opts.Color, strings.Join(colors, ", "))
+	}
+	if opts.Url != "" && !strings.HasPrefix(opts.Url, "https://images.unsplash.com/") {
+		return nil, fmt.Errorf("Card cover url %q is invalid. Only Unsplash images are supported", opts.Url)
+	}
+	if opts.Brightness != "" {
+		if opts.Brightness != "light" && opts.Brightness != "dark" {
+			return nil, fmt.Errorf("Card cover brightness %q is invalid. Only 'light' or 'dark'", opts.Brightness)
+		}
+		cover["brightness"] = opts.Brightness
+	}
+	if opts.Size != "" {
+		if opts.Size != "normal" && opts.Size != "full" {
+			return nil, fmt.Errorf("Card cover size %q is invalid. Only 'normal' or 'full'", opts.Size)
+		}
+		cover["size"] = opts.Size
+	}
+
+	body, err := c.client.PutJSON("/cards/"+c.Id, map[string]interface{}{"cover": cover})
+	if err != nil {
+		return nil, err
+	}
+	newCard := &Card{}
+	if err = json.Unmarshal(body, newCard); err != nil {
+		return nil, err
+	}
+	newCard.client = c.client
+	return newCard, nil
+}
+
+// SetCoordinates will place the card on the map
+// https://developers.trello.com/reference#cards-2
+func (c *Card) SetCoordinates(latitude, longitude float64) (*Card, error) {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil, fmt.Errorf("Coordinates %v,%v are invalid", latitude, longitude)
+	}
+	payload := url.Values{}
+	payload.Set("coordinates", Coordinates{latitude, longitude}.String())
+
+	return c.put("", payload)
+}
+
+// SetAddress will set the address of the card location
+// https://developers.trello.com/reference#cards-2
+func (c *Card) SetAddress(address string) (*Card, error) {
+	payload := url.Values{}
+	payload.Set("address", address)
+
+	return c.put("", payload)
+}
+
+// SetLocationName will set the name of the card location
+// https://developers.trello.com/reference#cards-2
+func (c *Card) SetLocationName(name string) (*Card, error) {
+	payload := url.Values{}
+	payload.Set("locationName", name)
+
+	return c.put("", payload)
+}
+
+// put will update the card, or one of its fields when resource is set, and
+// return the updated card
+func (c *Card) put(resource string, payload url.Values) (*Card, error) {
+	body, err := c.client.Put("/cards/"+c.Id+resource, payload)
+	if err != nil {
+		return nil, err
+	}
+	newCard := &Card{}
+	if err = json.Unmarshal(body, newCard); err != nil {
+		return nil, err
+	}
+	newCard.client = c.client
+	return newCard, nil
+}
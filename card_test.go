@@ -0,0 +1,53 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCardCoordinates(t *testing.T) {
+	tests := []struct {
+		json string
+		want *Coordinates
+	}{
+		{`{}`, nil},
+		{`{"coordinates":null}`, nil},
+		{`{"coordinates":""}`, &Coordinates{}},
+		{`{"coordinates":"50.0755, 14.4378"}`, &Coordinates{50.0755, 14.4378}},
+		{`{"coordinates":{"latitude":50.0755,"longitude":14.4378}}`, &Coordinates{50.0755, 14.4378}},
+	}
+	for _, test := range tests {
+		var card Card
+		if err := json.Unmarshal([]byte(test.json), &card); err != nil {
+			t.Errorf("Unmarshal(%s) failed: %v", test.json, err)
+			continue
+		}
+		got := card.Coordinates
+		if (got == nil) != (test.want == nil) || got != nil && *got != *test.want {
+			t.Errorf("Unmarshal(%s) gave coordinates %v, want %v", test.json, got, test.want)
+		}
+	}
+
+	for _, invalid := range []string{`{"coordinates":"50.0755"}`, `{"coordinates":"north,south"}`} {
+		var card Card
+		if err := json.Unmarshal([]byte(invalid), &card); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded with %v", invalid, card.Coordinates)
+		}
+	}
+}
@@ -21,18 +21,23 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 type ChecklistItem struct {
 	client   *Client
 	listID   string // back pointer to the parent Id
+	cardID   string // back pointer to the card of the parent
 	State    string `json:"state"`
 	Id       string `json:"id"`
 	Name     string `json:"name"`
 	NameData struct {
 		Emoji struct{} `json:"emoji"`
 	} `json:"nameData"`
-	Pos float32 `json:"pos"`
+	Pos         float64 `json:"pos"`
+	Due         Time    `json:"due"`
+	DueReminder *int    `json:"dueReminder"` // minutes before due, nil when unset
+	IdMember    string  `json:"idMember"`
 }
 
 func (i *ChecklistItem) Delete() error {
@@ -40,6 +45,46 @@ func (i *ChecklistItem) Delete() error {
 	return err
 }
 
+// SetDue will set the due date of the item, a zero t removing it
+// https://developers.trello.com/advanced-reference/card#put-1-cards-card-id-or-shortlink-checkitem-idcheckitem
+func (i *ChecklistItem) SetDue(t time.Time) (*ChecklistItem, error) {
+	payload := url.Values{}
+	if t.IsZero() {
+		payload.Set("due", "null")
+	} else {
+		payload.Set("due", t.Format(time.RFC3339))
+	}
+	return i.update(payload)
+}
+
+// SetMember will assign the item to the member, an empty memberId unassigning it
+// https://developers.trello.com/advanced-reference/card#put-1-cards-card-id-or-shortlink-checkitem-idcheckitem
+func (i *ChecklistItem) SetMember(memberId string) (*ChecklistItem, error) {
+	payload := url.Values{}
+	if memberId == "" {
+		payload.Set("idMember", "null")
+	} else {
+		payload.Set("idMember", memberId)
+	}
+	return i.update(payload)
+}
+
+func (i *ChecklistItem) update(payload url.Values) (*ChecklistItem, error) {
+	body, err := i.client.Put("/cards/"+i.cardID+"/checkItem/"+i.Id, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &ChecklistItem{}
+	if err = json.Unmarshal(body, item); err != nil {
+		return nil, err
+	}
+	item.client = i.client
+	item.listID = i.listID
+	item.cardID = i.cardID
+	return item, nil
+}
+
 // Checklist is a representation of a checklist on a trello card
 // https://developers.trello.com/advanced-reference/checklist
 type Checklist struct {
@@ -48,7 +93,7 @@ type Checklist struct {
 	Name       string          `json:"name"`
 	IdBoard    string          `json:"idBoard"`
 	IdCard     string          `json:"idCard"`
-	Pos        float32         `json:"pos"`
+	Pos        float64         `json:"pos"`
 	CheckItems []ChecklistItem `json:"checkItems"`
 }
 
@@ -62,7 +107,7 @@ func (c *Checklist) Delete() error {
 // AddItem will add a new item to the given checklist. The position will default to 'bottom'
 // if nil and the item will default to 'unchecked'.
 //   name must have a length 1 <= length <= 16384
-//   pos can take the values 'top', 'bottom', or a positive integer
+//   pos can take the values 'top', 'bottom', or a positive number
 // https://developers.trello.com/advanced-reference/checklist#post-1-checklists-idchecklist-checkitems
 func (c *Checklist) AddItem(name string, pos *string, checked *bool) (*ChecklistItem, error) {
 	payload := url.Values{}
@@ -71,16 +116,11 @@ func (c *Checklist) AddItem(name string, pos *string, checked *bool) (*Checklist
 	}
 	payload.Set("name", name)
 	if pos != nil {
-		if *pos != "top" && *pos != "bottom" {
-			i, err := strconv.Atoi(*pos)
-			if err != nil {
-				return nil, err
-			}
-			if i < 1 {
-				return nil, fmt.Errorf("Checklist item position %q is invalid. Only 'top', 'bottom', or a positive integer", *pos)
-			}
+		p, err := formatPosition(*pos)
+		if err != nil {
+			return nil, err
 		}
-		payload.Set("pos", *pos)
+		payload.Set("pos", p)
 	}
 	if checked != nil {
 		payload.Set("checked", strconv.FormatBool(*checked))
@@ -96,6 +136,22 @@ func (c *Checklist) AddItem(name string, pos *string, checked *bool) (*Checklist
 	}
 	item.client = c.client
 	item.listID = c.Id
+	item.cardID = c.IdCard
 
 	return item, err
 }
+
+// SetPosition will move the checklist within its card. pos can take the values
+// "top", "bottom" or a positive number.
+// https://developers.trello.com/advanced-reference/checklist#put-1-checklists-idchecklist-pos
+func (c *Checklist) SetPosition(pos interface{}) error {
+	position, err := formatPosition(pos)
+	if err != nil {
+		return err
+	}
+	payload := url.Values{}
+	payload.Set("value", position)
+
+	_, err = c.client.Put("/checklists/"+c.Id+"/pos", payload)
+	return err
+}
@@ -17,47 +17,244 @@ limitations under the License.
 package trello
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
+const defaultBaseURL = "https://api.trello.com"
+
+// ErrResponseTooLarge is the error returned when a response exceeds the size
+// set with WithMaxResponseBytes
+var ErrResponseTooLarge = errors.New("trello: response exceeds the maximum size")
+
 type Client struct {
-	client   *http.Client
-	endpoint string
-	version  string
+	client       *http.Client
+	baseURL      string
+	version      string
+	limiter      Limiter
+	dryRun       *DryRun
+	userAgent    string
+	retries      int
+	retryBackoff time.Duration
+
+	maxResponseBytes int64
 }
 
 func (c *Client) do(req *http.Request) ([]byte, error) {
-	resp, err := c.client.Do(req)
+	body, err := c.open(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	defer body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	return ioutil.ReadAll(body)
+}
+
+// open will send the request and return the body of the successful response,
+// capped to the size set with WithMaxResponseBytes. The caller must close it.
+func (c *Client) open(req *http.Request) (io.ReadCloser, error) {
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, err
 	}
+
+	body := resp.Body
+	if c.maxResponseBytes > 0 {
+		body = &limitedBody{ReadCloser: body, remaining: c.maxResponseBytes}
+	}
 	if resp.StatusCode != 200 {
-		err = fmt.Errorf("Received unexpected status %d while trying to retrieve the server data with \"%s\"", resp.StatusCode, string(body))
-		return nil, err
+		defer body.Close()
+		data, err := ioutil.ReadAll(body)
+		if err != nil && err != ErrResponseTooLarge {
+			return nil, err
+		}
+		return nil, newError(resp.StatusCode, data)
 	}
 	return body, nil
 }
 
+// getJSON will decode the response to a GET request into v as it is read,
+// rather than buffering it whole as Get does.
+func (c *Client) getJSON(resource string, v interface{}) error {
+	return c.getJSONContext(context.Background(), resource, v)
+}
+
+// getJSONContext is getJSON with a context cancelling the request
+func (c *Client) getJSONContext(ctx context.Context, resource string, v interface{}) error {
+	body, err := c.getStream(ctx, resource)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if err = json.NewDecoder(body).Decode(v); err != nil {
+		return err
+	}
+	// drain what follows the JSON value so the connection can be reused
+	_, err = io.Copy(ioutil.Discard, body)
+	return err
+}
+
+// limitedBody fails with ErrResponseTooLarge once more than remaining bytes
+// are read, unlike io.LimitReader which would silently truncate the JSON.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.ReadCloser.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), ErrResponseTooLarge
+	}
+	return n, err
+}
+
+// send will send the request, retrying it as configured with WithRetry.
+// Requests rejected with status 429 are always safe to retry, whereas server
+// and network errors are only retried for idempotent methods. The delay
+// before a retry is the one given by Retry-After when the response has it,
+// and the wait ends early when the context of the request is done.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			c.limiter.Wait()
+		}
+		r := req
+		if attempt > 0 {
+			r = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+
+		resp, err := c.client.Do(r)
+		if attempt >= c.retries || !shouldRetry(r.Method, resp, err) {
+			return resp, err
+		}
+		delay := c.retryBackoff << uint(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				delay = after
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if !sleepContext(req.Context(), delay) {
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// retryAfter will return the delay requested by the Retry-After header of
+// resp, given either in seconds or as a date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+	return 0, false
+}
+
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if method == "POST" {
+		return false
+	}
+	return err != nil || resp.StatusCode >= 500
+}
+
+// url will build the URL of an API resource, e.g. "/boards/abc?fields=name".
+// All the calls go through it so that they honor the base URL and API version
+// of the client.
+func (c *Client) url(resource string) string {
+	if !strings.HasPrefix(resource, "/") {
+		resource = "/" + resource
+	}
+	return strings.TrimRight(c.baseURL, "/") + "/" + c.version + resource
+}
+
+// SetBaseURL will send the API calls to baseURL instead of
+// https://api.trello.com, e.g. to an httptest server or an API gateway. The
+// API version is appended to it.
+func (c *Client) SetBaseURL(baseURL string) error {
+	if err := validateBaseURL(baseURL); err != nil {
+		return err
+	}
+	c.baseURL = baseURL
+	return nil
+}
+
+func validateBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("Base URL %q is invalid. It must be absolute", baseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("Base URL %q is invalid. It must not have a query or fragment", baseURL)
+	}
+	return nil
+}
+
 func (c *Client) Get(resource string) ([]byte, error) {
-	req, err := http.NewRequest("GET", c.endpoint+resource, nil)
+	req, err := http.NewRequest("GET", c.url(resource), nil)
 	if err != nil {
 		return nil, err
 	}
 	return c.do(req)
 }
 
+// getStream will send a GET request and return the body of the response,
+// which the caller must close.
+func (c *Client) getStream(ctx context.Context, resource string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url(resource), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.open(req)
+}
+
 func (c *Client) Post(resource string, data url.Values) ([]byte, error) {
-	req, err := http.NewRequest("POST", c.endpoint+resource, strings.NewReader(data.Encode()))
+	if c.dryRun != nil {
+		return c.dryRun.record("POST", resource, data, nil)
+	}
+	req, err := http.NewRequest("POST", c.url(resource), strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -66,8 +263,74 @@ func (c *Client) Post(resource string, data url.Values) ([]byte, error) {
 	return c.do(req)
 }
 
+// PostFile will upload the content of r as a multipart/form-data file field,
+// along with the given form values.
+func (c *Client) PostFile(resource string, data url.Values, field, filename string, r io.Reader) ([]byte, error) {
+	if c.dryRun != nil {
+		return c.dryRun.record("POST", resource, data, nil)
+	}
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	for key, values := range data {
+		for _, value := range values {
+			if err := w.WriteField(key, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	part, err := w.CreateFormFile(field, filename)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(part, r); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", c.url(resource), buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	return c.do(req)
+}
+
+// PostJSON will send v encoded as JSON, for the endpoints which take a JSON
+// body rather than form values.
+func (c *Client) PostJSON(resource string, v interface{}) ([]byte, error) {
+	return c.sendJSON("POST", resource, v)
+}
+
+// PutJSON will send v encoded as JSON, see PostJSON
+func (c *Client) PutJSON(resource string, v interface{}) ([]byte, error) {
+	return c.sendJSON("PUT", resource, v)
+}
+
+func (c *Client) sendJSON(method, resource string, v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if c.dryRun != nil {
+		return c.dryRun.record(method, resource, nil, data)
+	}
+	req, err := http.NewRequest(method, c.url(resource), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.do(req)
+}
+
 func (c *Client) Put(resource string, data url.Values) ([]byte, error) {
-	req, err := http.NewRequest("PUT", c.endpoint+resource, strings.NewReader(data.Encode()))
+	if c.dryRun != nil {
+		return c.dryRun.record("PUT", resource, data, nil)
+	}
+	req, err := http.NewRequest("PUT", c.url(resource), strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +340,10 @@ func (c *Client) Put(resource string, data url.Values) ([]byte, error) {
 }
 
 func (c *Client) Delete(resource string) ([]byte, error) {
-	req, err := http.NewRequest("DELETE", c.endpoint+resource, nil)
+	if c.dryRun != nil {
+		return c.dryRun.record("DELETE", resource, nil, nil)
+	}
+	req, err := http.NewRequest("DELETE", c.url(resource), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +351,13 @@ func (c *Client) Delete(resource string) ([]byte, error) {
 	return c.do(req)
 }
 
+// SetRateLimiter will replace the limiter the client waits on before each
+// request, which by default allows the 100 requests per 10 seconds Trello
+// grants each token. A nil limiter disables rate limiting.
+func (c *Client) SetRateLimiter(limiter Limiter) {
+	c.limiter = limiter
+}
+
 type bearerRoundTripper struct {
 	Delegate http.RoundTripper
 	key      string
@@ -92,14 +365,15 @@ type bearerRoundTripper struct {
 }
 
 func (b *bearerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	if b.Delegate == nil {
-		b.Delegate = http.DefaultTransport
+	delegate := b.Delegate
+	if delegate == nil {
+		delegate = http.DefaultTransport
 	}
 	values := req.URL.Query()
-	values.Add("key", b.key)
-	values.Add("token", *b.token)
+	values.Set("key", b.key)
+	values.Set("token", *b.token)
 	req.URL.RawQuery = values.Encode()
-	return b.Delegate.RoundTrip(req)
+	return delegate.RoundTrip(req)
 }
 
 // NewBearerTokenTransport will return an http.RoundTripper which will add the
@@ -117,14 +391,7 @@ func NewBearerTokenTransport(applicationKey string, token *string) *bearerRoundT
 
 // NewCustomClient can be used to implement your own client
 func NewCustomClient(client *http.Client) (*Client, error) {
-	version := "1"
-	endpoint := "https://api.trello.com/" + version
-
-	return &Client{
-		client:   client,
-		endpoint: endpoint,
-		version:  version,
-	}, nil
+	return NewClient("", "", WithHTTPClient(client))
 }
 
 // NewAuthClient will create a trello client which allows authentication. It uses
@@ -138,9 +405,35 @@ func NewAuthClient(applicationKey string, token *string) (*Client, error) {
 	return NewCustomClient(client)
 }
 
-// NewClient returns a client needed to make trello API calls. If transport is nil
-// all API calls will be unauthenticated. If you have a bearer token, NewBearerTokenTransport()
-// may be helpful in making calls authenticated.
-func NewClient() (*Client, error) {
-	return NewCustomClient(http.DefaultClient)
+// NewClient returns a client needed to make trello API calls, configured by the
+// given options. If applicationKey is empty all API calls will be
+// unauthenticated, otherwise the key and token are added to each call as with
+// NewBearerTokenTransport.
+//
+//	client, err := trello.NewClient(key, token,
+//		trello.WithUserAgent("sprint-bot/1.0"),
+//		trello.WithRetry(3, time.Second))
+func NewClient(applicationKey, token string, opts ...Option) (*Client, error) {
+	c := &Client{
+		client:  http.DefaultClient,
+		baseURL: defaultBaseURL,
+		version: "1",
+		limiter: newRateLimiter(TokenRateLimit, RatePeriod),
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if applicationKey != "" {
+		client := *c.client
+		client.Transport = &bearerRoundTripper{
+			Delegate: client.Transport,
+			key:      applicationKey,
+			token:    &token,
+		}
+		c.client = &client
+	}
+	return c, nil
 }
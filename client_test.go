@@ -0,0 +1,133 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newLimitedClient returns a client limited to max bytes per response, and
+// answering every call with status and body
+func newLimitedClient(t *testing.T, max int64, status int, body string) *Client {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+	if err := WithMaxResponseBytes(max)(client); err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	const board = `{"id":"b1","name":"Board"}`
+	size := int64(len(board))
+
+	tests := []struct {
+		name string
+		max  int64
+		body string
+		err  error
+	}{
+		{"no limit", 0, board, nil},
+		{"at the limit", size, board, nil},
+		{"over the limit", size - 1, board, ErrResponseTooLarge},
+		{"trailing bytes within the limit", size + 2, board + "\n\n", nil},
+		{"trailing bytes over the limit", size + 2, board + "\n\n\n", ErrResponseTooLarge},
+	}
+	for _, test := range tests {
+		client := newLimitedClient(t, test.max, http.StatusOK, test.body)
+
+		var decoded Board
+		if err := client.getJSON("/boards/b1", &decoded); err != test.err {
+			t.Errorf("%s: getJSON returned %v, want %v", test.name, err, test.err)
+		} else if err == nil && decoded.Name != "Board" {
+			t.Errorf("%s: getJSON decoded %+v", test.name, decoded)
+		}
+
+		body, err := client.Get("/boards/b1")
+		if err != test.err {
+			t.Errorf("%s: Get returned %v, want %v", test.name, err, test.err)
+		} else if err == nil && string(body) != test.body {
+			t.Errorf("%s: Get returned %q, want %q", test.name, body, test.body)
+		}
+	}
+}
+
+func TestMaxResponseBytesError(t *testing.T) {
+	body := "invalid id" + strings.Repeat(" ", 100)
+	client := newLimitedClient(t, 10, http.StatusNotFound, body)
+
+	_, err := client.Get("/boards/missing")
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Get returned %T %v, want *Error", err, err)
+	}
+	if e.StatusCode != http.StatusNotFound || e.Message != "invalid id" || len(e.Body) != 10 {
+		t.Errorf("Get returned %+v, want status 404 with the first 10 bytes of the body", e)
+	}
+}
+
+func TestWithMaxResponseBytesInvalid(t *testing.T) {
+	if _, err := NewClient("", "", WithMaxResponseBytes(-1)); err == nil {
+		t.Error("WithMaxResponseBytes(-1) was accepted")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "API_TOKEN_LIMIT_EXCEEDED", http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id":"b1"}`))
+	})
+	// the backoff would time the test out if Retry-After were ignored
+	if err := WithRetry(1, time.Hour)(client); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Get("/boards/b1"); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 {
+		t.Errorf("Get sent %d requests, want 2", attempts)
+	}
+}
+
+func TestRetryCancelled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	})
+	if err := WithRetry(1, time.Hour)(client); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var board Board
+	if err := client.getJSONContext(ctx, "/boards/b1", &board); err != context.DeadlineExceeded {
+		t.Errorf("getJSONContext returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
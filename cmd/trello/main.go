@@ -0,0 +1,268 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command trello is a small command line client for the Trello API built on
+// go-trello.
+//
+// The application key and token are read from the TRELLO_KEY and TRELLO_TOKEN
+// environment variables, or else from $HOME/.config/trello/config.json:
+//
+//	{"key": "application-key", "token": "token"}
+//
+// Boards and cards can be given by id, shortLink or URL.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/VojtechVitek/go-trello"
+)
+
+const usage = `Usage: trello <command> [arguments]
+
+Commands:
+  boards                          list your open boards
+  lists <board>                   list the open lists of a board
+  cards <list-id>                 list the cards of a list
+  create-card [-desc d] [-pos p] <list-id> <name>
+                                  create a card
+  move-card <card> <list-id>      move a card to another list
+  comment <card> <text>           add a comment to a card
+  export [-o file] <board>        export a board as JSON
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	client, err := newClient()
+	if err == nil {
+		err = run(client, flag.Arg(0), flag.Args()[1:])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "trello:", err)
+		os.Exit(1)
+	}
+}
+
+func run(client *trello.Client, command string, args []string) error {
+	switch command {
+	case "boards":
+		return listBoards(client)
+	case "lists":
+		return listLists(client, args)
+	case "cards":
+		return listCards(client, args)
+	case "create-card":
+		return createCard(client, args)
+	case "move-card":
+		return moveCard(client, args)
+	case "comment":
+		return comment(client, args)
+	case "export":
+		return export(client, args)
+	}
+	flag.Usage()
+	return fmt.Errorf("unknown command %q", command)
+}
+
+type credentials struct {
+	Key   string `json:"key"`
+	Token string `json:"token"`
+}
+
+func newClient() (*trello.Client, error) {
+	creds := credentials{
+		Key:   os.Getenv("TRELLO_KEY"),
+		Token: os.Getenv("TRELLO_TOKEN"),
+	}
+	if creds.Key == "" || creds.Token == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(filepath.Join(home, ".config", "trello", "config.json"))
+		if os.IsNotExist(err) {
+			return nil, errors.New("no credentials, set TRELLO_KEY and TRELLO_TOKEN")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(data, &creds); err != nil {
+			return nil, err
+		}
+	}
+	return trello.NewClient(creds.Key, creds.Token, trello.WithUserAgent("go-trello-cli"))
+}
+
+func getBoard(client *trello.Client, board string) (*trello.Board, error) {
+	if strings.Contains(board, "/") {
+		return client.BoardByShortLink(board)
+	}
+	return client.Board(board)
+}
+
+func getCard(client *trello.Client, card string) (*trello.Card, error) {
+	if strings.Contains(card, "/") {
+		return client.CardByShortLink(card)
+	}
+	return client.Card(card)
+}
+
+func listBoards(client *trello.Client) error {
+	boards, err := client.MyBoards("name", "closed", "shortUrl")
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, board := range boards {
+		if !board.Closed {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", board.Id, board.Name, board.ShortUrl)
+		}
+	}
+	return w.Flush()
+}
+
+func listLists(client *trello.Client, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: trello lists <board>")
+	}
+	board, err := getBoard(client, args[0])
+	if err != nil {
+		return err
+	}
+	lists, err := board.Lists()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, list := range lists {
+		fmt.Fprintf(w, "%s\t%s\n", list.Id, list.Name)
+	}
+	return w.Flush()
+}
+
+func listCards(client *trello.Client, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: trello cards <list-id>")
+	}
+	list, err := client.List(args[0])
+	if err != nil {
+		return err
+	}
+	cards, err := list.Cards()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, card := range cards {
+		due := ""
+		if !card.Due.IsZero() {
+			due = card.Due.Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", card.Id, card.Name, due, card.ShortUrl)
+	}
+	return w.Flush()
+}
+
+func createCard(client *trello.Client, args []string) error {
+	flags := flag.NewFlagSet("create-card", flag.ContinueOnError)
+	desc := flags.String("desc", "", "description of the card")
+	pos := flags.String("pos", "bottom", "position of the card: top, bottom or a number")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errors.New("usage: trello create-card [-desc d] [-pos p] <list-id> <name>")
+	}
+	list, err := client.List(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	card, err := list.AddCard(trello.AddCardOpts{
+		Name:     flags.Arg(1),
+		Desc:     *desc,
+		Position: *pos,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(card.Id, card.ShortUrl)
+	return nil
+}
+
+func moveCard(client *trello.Client, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: trello move-card <card> <list-id>")
+	}
+	card, err := getCard(client, args[0])
+	if err != nil {
+		return err
+	}
+	_, err = card.MoveToList(args[1])
+	return err
+}
+
+func comment(client *trello.Client, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: trello comment <card> <text>")
+	}
+	card, err := getCard(client, args[0])
+	if err != nil {
+		return err
+	}
+	_, err = card.AddComment(args[1])
+	return err
+}
+
+func export(client *trello.Client, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := flags.String("o", "", "file to write the export to, standard output by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: trello export [-o file] <board>")
+	}
+	board, err := getBoard(client, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return board.Export(os.Stdout)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err = board.Export(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// CSVColumn is a column of the CSV written by Board.ExportCSV
+type CSVColumn string
+
+const (
+	CSVName    CSVColumn = "name"
+	CSVList    CSVColumn = "list"
+	CSVLabels  CSVColumn = "labels"
+	CSVMembers CSVColumn = "members"
+	CSVDue     CSVColumn = "due"
+	CSVDesc    CSVColumn = "desc"
+	CSVUrl     CSVColumn = "url"
+
+	csvCustomFieldPrefix = "customField:"
+)
+
+var csvTitles = map[CSVColumn]string{
+	CSVName:    "Name",
+	CSVList:    "List",
+	CSVLabels:  "Labels",
+	CSVMembers: "Members",
+	CSVDue:     "Due",
+	CSVDesc:    "Description",
+	CSVUrl:     "URL",
+}
+
+// CSVCustomField returns the column holding the values of the custom field
+// with the given name
+func CSVCustomField(name string) CSVColumn {
+	return CSVColumn(csvCustomFieldPrefix + name)
+}
+
+// ExportCSVOpts configures Board.ExportCSV
+type ExportCSVOpts struct {
+	// Columns are the columns to write, in order. When empty they are the
+	// name, list, labels, members and due date of the cards, followed by all
+	// the custom fields of the board.
+	Columns []CSVColumn
+	// Comma is the field delimiter, ',' by default. Spreadsheets in some
+	// locales expect ';'.
+	Comma rune
+}
+
+// csvField is a custom field along with its options, which the customFields
+// endpoint returns nested
+type csvField struct {
+	Id      string              `json:"id"`
+	Name    string              `json:"name"`
+	Type    string              `json:"type"`
+	Options []CustomFieldOption `json:"options"`
+}
+
+// ExportCSV will write to w a header row followed by one row per open card of
+// the board. Lists, members and custom field options are written by name
+// rather than by id; labels without a name are written by color, and due
+// dates in RFC 3339 format.
+//
+//	err := board.ExportCSV(ctx, f, trello.ExportCSVOpts{
+//		Columns: []trello.CSVColumn{trello.CSVName, trello.CSVList, trello.CSVCustomField("Estimate")},
+//	})
+func (b *Board) ExportCSV(ctx context.Context, w io.Writer, opts ExportCSVOpts) error {
+	var fields []csvField
+	var lists []List
+	var members []Member
+	var cards []Card
+	requests := []struct {
+		resource string
+		v        interface{}
+	}{
+		{"/boards/" + b.Id + "/customFields", &fields},
+		{"/boards/" + b.Id + "/lists?filter=all", &lists},
+		{"/boards/" + b.Id + "/members?fields=fullName,username", &members},
+		{"/boards/" + b.Id + "/cards?customFieldItems=true", &cards},
+	}
+	for _, r := range requests {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := b.client.getJSONContext(ctx, r.resource, r.v); err != nil {
+			return err
+		}
+	}
+
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = []CSVColumn{CSVName, CSVList, CSVLabels, CSVMembers, CSVDue}
+		for _, field := range fields {
+			columns = append(columns, CSVCustomField(field.Name))
+		}
+	}
+	header := make([]string, len(columns))
+	columnFields := make([]*csvField, len(columns))
+	for i, column := range columns {
+		if title, ok := csvTitles[column]; ok {
+			header[i] = title
+			continue
+		}
+		name := strings.TrimPrefix(string(column), csvCustomFieldPrefix)
+		if name == string(column) {
+			return fmt.Errorf("CSV column %q is invalid", column)
+		}
+		for j := range fields {
+			if fields[j].Name == name {
+				columnFields[i] = &fields[j]
+				break
+			}
+		}
+		if columnFields[i] == nil {
+			return fmt.Errorf("Board %s has no custom field named %q", b.Id, name)
+		}
+		header[i] = name
+	}
+
+	listNames := map[string]string{}
+	for _, list := range lists {
+		listNames[list.Id] = list.Name
+	}
+	memberNames := map[string]string{}
+	for _, member := range members {
+		memberNames[member.Id] = member.FullName
+		if member.FullName == "" {
+			memberNames[member.Id] = member.Username
+		}
+	}
+
+	out := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		out.Comma = opts.Comma
+	}
+	if err := out.Write(header); err != nil {
+		return err
+	}
+	row := make([]string, len(columns))
+	for _, card := range cards {
+		for i, column := range columns {
+			switch column {
+			case CSVName:
+				row[i] = card.Name
+			case CSVList:
+				row[i] = listNames[card.IdList]
+			case CSVLabels:
+				row[i] = csvLabels(card.Labels)
+			case CSVMembers:
+				row[i] = csvMembers(card.IdMembers, memberNames)
+			case CSVDue:
+				row[i] = ""
+				if !card.Due.IsZero() {
+					row[i] = card.Due.Format(time.RFC3339)
+				}
+			case CSVDesc:
+				row[i] = card.Desc
+			case CSVUrl:
+				row[i] = card.Url
+			default:
+				row[i] = csvCustomFieldValue(columnFields[i], card.CustomFieldItems)
+			}
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func csvLabels(labels []Label) string {
+	names := make([]string, len(labels))
+	for i, label := range labels {
+		names[i] = label.Name
+		if label.Name == "" {
+			names[i] = label.Color
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func csvMembers(ids []string, memberNames map[string]string) string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = memberNames[id]
+		if names[i] == "" {
+			names[i] = id
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+func csvCustomFieldValue(field *csvField, items []CustomFieldItem) string {
+	for _, item := range items {
+		if item.IdCustomField != field.Id {
+			continue
+		}
+		if field.Type == "list" {
+			for _, option := range field.Options {
+				if option.Id == item.IdValue {
+					return option.Value.Text
+				}
+			}
+			return item.IdValue
+		}
+		for _, value := range item.Value {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+var csvRoutes = map[string]string{
+	"/1/boards/b1/customFields": `[
+		{"id":"f1","name":"Estimate","type":"number"},
+		{"id":"f2","name":"Priority","type":"list","options":[{"id":"o1","idCustomField":"f2","value":{"text":"High"}}]}
+	]`,
+	"/1/boards/b1/lists":   `[{"id":"l1","name":"To do"}]`,
+	"/1/boards/b1/members": `[{"id":"m1","fullName":"Ada Lovelace"},{"id":"m2","username":"bob"}]`,
+	"/1/boards/b1/cards": `[
+		{
+			"id":"c1","name":"Write, docs","idList":"l1","idMembers":["m1","m2"],
+			"labels":[{"name":"Bug","color":"red"},{"name":"","color":"green"}],
+			"due":"2016-02-24T13:45:52.391Z","url":"https://trello.com/c/abc",
+			"customFieldItems":[{"idCustomField":"f1","value":{"number":"3"}},{"idCustomField":"f2","idValue":"o1"}]
+		},
+		{"id":"c2","name":"Empty","idList":"l1","due":null}
+	]`,
+}
+
+func TestBoardExportCSV(t *testing.T) {
+	board := &Board{client: newTestClient(t, serveRoutes(t, csvRoutes)), Id: "b1"}
+
+	tests := []struct {
+		opts ExportCSVOpts
+		want string
+	}{
+		{
+			ExportCSVOpts{},
+			"Name,List,Labels,Members,Due,Estimate,Priority\n" +
+				"\"Write, docs\",To do,\"Bug, green\",\"Ada Lovelace, bob\",2016-02-24T13:45:52Z,3,High\n" +
+				"Empty,To do,,,,,\n",
+		},
+		{
+			ExportCSVOpts{Columns: []CSVColumn{CSVCustomField("Priority"), CSVName, CSVUrl}, Comma: ';'},
+			"Priority;Name;URL\n" +
+				"High;Write, docs;https://trello.com/c/abc\n" +
+				";Empty;\n",
+		},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := board.ExportCSV(context.Background(), &buf, test.opts); err != nil {
+			t.Errorf("ExportCSV(%+v) failed: %v", test.opts, err)
+			continue
+		}
+		if buf.String() != test.want {
+			t.Errorf("ExportCSV(%+v) wrote\n%s\nwant\n%s", test.opts, buf.String(), test.want)
+		}
+	}
+
+	for _, column := range []CSVColumn{"title", CSVCustomField("Missing")} {
+		var buf bytes.Buffer
+		if err := board.ExportCSV(context.Background(), &buf, ExportCSVOpts{Columns: []CSVColumn{column}}); err == nil {
+			t.Errorf("ExportCSV with column %q succeeded", column)
+		}
+	}
+}
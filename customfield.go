@@ -0,0 +1,136 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// CustomField is a field defined by the Custom Fields Power-Up on a board
+// https://developers.trello.com/reference#custom-fields
+type CustomField struct {
+	client     *Client
+	Id         string  `json:"id"`
+	IdModel    string  `json:"idModel"`
+	ModelType  string  `json:"modelType"`
+	FieldGroup string  `json:"fieldGroup"`
+	Name       string  `json:"name"`
+	Pos        float64 `json:"pos"`
+	Type       string  `json:"type"` // checkbox, date, list, number or text
+	Display    struct {
+		CardFront bool `json:"cardFront"`
+	} `json:"display"`
+}
+
+// CustomFieldOption is one of the values of a list custom field
+type CustomFieldOption struct {
+	client        *Client
+	Id            string `json:"id"`
+	IdCustomField string `json:"idCustomField"`
+	Value         struct {
+		Text string `json:"text"`
+	} `json:"value"`
+	Color string  `json:"color"`
+	Pos   float64 `json:"pos"`
+}
+
+// CustomFieldItem is the value of a custom field on a card, returned with
+// the cards when requested with customFieldItems=true. Value holds one of the
+// keys text, number, date or checked, whereas list fields set IdValue to the
+// id of the selected option instead.
+type CustomFieldItem struct {
+	Id            string            `json:"id"`
+	IdCustomField string            `json:"idCustomField"`
+	IdModel       string            `json:"idModel"`
+	ModelType     string            `json:"modelType"`
+	IdValue       string            `json:"idValue"`
+	Value         map[string]string `json:"value"`
+}
+
+var customFieldOptionColors = []string{"none", "green", "yellow", "orange", "red", "purple", "blue", "sky", "lime", "pink", "black"}
+
+func (b *Board) CustomFields() (fields []CustomField, err error) {
+	if err = b.client.getJSON("/boards/"+b.Id+"/customFields", &fields); err != nil {
+		return
+	}
+	for i := range fields {
+		fields[i].client = b.client
+	}
+	return
+}
+
+func (f *CustomField) Options() (options []CustomFieldOption, err error) {
+	if err = f.client.getJSON("/customFields/"+f.Id+"/options", &options); err != nil {
+		return
+	}
+	for i := range options {
+		options[i].client = f.client
+	}
+	return
+}
+
+// AddOption will add an option to a list custom field. color is one of
+// green, yellow, orange, red, purple, blue, sky, lime, pink or black, or empty
+// for no color. pos can take the values "top", "bottom" or a positive number.
+// https://developers.trello.com/reference#customfieldsidoptions-1
+func (f *CustomField) AddOption(value, color string, pos interface{}) (*CustomFieldOption, error) {
+	if f.Type != "" && f.Type != "list" {
+		return nil, fmt.Errorf("Custom field %q is of type %s, only list fields have options", f.Name, f.Type)
+	}
+	if color == "" {
+		color = "none"
+	}
+	if !containsString(customFieldOptionColors, color) {
+		return nil, fmt.Errorf("Custom field option color %q is invalid", color)
+	}
+	option := map[string]interface{}{
+		"value": map[string]string{"text": value},
+		"color": color,
+	}
+	if pos != nil {
+		position, err := formatPosition(pos)
+		if err != nil {
+			return nil, err
+		}
+		if n, err := strconv.ParseFloat(position, 64); err == nil {
+			option["pos"] = n
+		} else {
+			option["pos"] = position
+		}
+	}
+
+	body, err := f.client.PostJSON("/customFields/"+f.Id+"/options", option)
+	if err != nil {
+		return nil, err
+	}
+
+	newOption := &CustomFieldOption{}
+	if err = json.Unmarshal(body, newOption); err != nil {
+		return nil, err
+	}
+	newOption.client = f.client
+	return newOption, nil
+}
+
+// Delete will remove the option from its custom field
+// https://developers.trello.com/reference#customfieldsidoptionsidcustomfieldoption-1
+func (o *CustomFieldOption) Delete() error {
+	_, err := o.client.Delete("/customFields/" + o.IdCustomField + "/options/" + o.Id)
+	return err
+}
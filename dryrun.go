@@ -0,0 +1,90 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"errors"
+	"net/url"
+	"sync"
+)
+
+// ErrDryRun is the error returned by the calls intercepted by a DryRun with
+// FailRequests set
+var ErrDryRun = errors.New("trello: request not sent in dry-run mode")
+
+// RecordedRequest is a POST, PUT or DELETE request intercepted by a DryRun
+type RecordedRequest struct {
+	Method   string
+	Resource string
+	Data     url.Values
+	JSON     []byte // body of the requests sending JSON instead of form values
+}
+
+// DryRun records the mutating requests of a client instead of sending them,
+// which lets a migration script be previewed before it touches real boards.
+// GET requests are still sent so that the script can run as usual.
+//
+//	plan := &trello.DryRun{}
+//	client.SetDryRun(plan)
+//	// ... run the migration
+//	for _, req := range plan.Requests() {
+//		fmt.Println(req.Method, req.Resource, req.Data.Encode())
+//	}
+type DryRun struct {
+	// FailRequests makes the intercepted calls return ErrDryRun. Otherwise
+	// they succeed with an empty response, and the objects they return are
+	// zero values.
+	FailRequests bool
+
+	mu       sync.Mutex
+	requests []RecordedRequest
+}
+
+// Requests will return the requests recorded so far, in order
+func (d *DryRun) Requests() []RecordedRequest {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]RecordedRequest(nil), d.requests...)
+}
+
+func (d *DryRun) record(method, resource string, data url.Values, jsonBody []byte) ([]byte, error) {
+	copied := url.Values{}
+	for key, values := range data {
+		copied[key] = append([]string(nil), values...)
+	}
+
+	d.mu.Lock()
+	d.requests = append(d.requests, RecordedRequest{
+		Method:   method,
+		Resource: resource,
+		Data:     copied,
+		JSON:     jsonBody,
+	})
+	d.mu.Unlock()
+
+	if d.FailRequests {
+		return nil, ErrDryRun
+	}
+	return []byte("{}"), nil
+}
+
+// SetDryRun will make the client record its POST, PUT and DELETE requests in d
+// instead of sending them. A nil d sends them again.
+func (c *Client) SetDryRun(d *DryRun) {
+	c.dryRun = d
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// Emoji is one of the emoji available for reactions and in comments
+// https://developers.trello.com/reference#emoji
+type Emoji struct {
+	Unified    string   `json:"unified"`
+	Native     string   `json:"native"`
+	Name       string   `json:"name"`
+	ShortName  string   `json:"shortName"`
+	ShortNames []string `json:"shortNames"`
+	Text       string   `json:"text"`
+	Texts      []string `json:"texts"`
+	Category   string   `json:"category"`
+	SheetX     int      `json:"sheetX"`
+	SheetY     int      `json:"sheetY"`
+	Tts        string   `json:"tts"`
+	Keywords   []string `json:"keywords"`
+}
+
+// EmojiList is the set of emoji Trello supports, as returned by Client.Emoji
+type EmojiList struct {
+	Trello []Emoji `json:"trello"`
+}
+
+// CustomEmoji is an emoji uploaded by a member
+// https://developers.trello.com/reference#membersidcustomemoji
+type CustomEmoji struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+func (c *Client) Emoji() (emoji *EmojiList, err error) {
+	err = c.getJSON("/emoji", &emoji)
+	return
+}
+
+func (m *Member) CustomEmoji() (emoji []CustomEmoji, err error) {
+	err = m.client.getJSON("/members/"+m.Id+"/customEmoji", &emoji)
+	return
+}
+
+// CreateCustomEmoji will upload the image read from file as a new custom emoji
+// of the member. name must be 2 to 64 characters long.
+// https://developers.trello.com/reference#membersidcustomemoji-1
+func (m *Member) CreateCustomEmoji(name string, file io.Reader) (*CustomEmoji, error) {
+	if len(name) < 2 || len(name) > 64 {
+		return nil, fmt.Errorf("Custom emoji name %q has invalid length. 2 <= length <= 64", name)
+	}
+	payload := url.Values{}
+	payload.Set("name", name)
+
+	body, err := m.client.PostFile("/members/"+m.Id+"/customEmoji", payload, "file", name, file)
+	if err != nil {
+		return nil, err
+	}
+
+	emoji := &CustomEmoji{}
+	if err = json.Unmarshal(body, emoji); err != nil {
+		return nil, err
+	}
+	return emoji, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Enterprise is a Trello Enterprise account grouping several organizations
+// https://developers.trello.com/reference#enterprises
+type Enterprise struct {
+	client          *Client
+	Id              string   `json:"id"`
+	Name            string   `json:"name"`
+	DisplayName     string   `json:"displayName"`
+	LogoHash        string   `json:"logoHash"`
+	IdAdmins        []string `json:"idAdmins"`
+	IdMembers       []string `json:"idMembers"`
+	IdOrganizations []string `json:"idOrganizations"`
+	Products        []int    `json:"products"`
+	Prefs           struct {
+		SsoOnly        bool   `json:"ssoOnly"`
+		SignupBanner   string `json:"signupBanner"`
+		MobileLockdown bool   `json:"mobileLockdown"`
+	} `json:"prefs"`
+}
+
+// TransferrableStatus tells whether an organization can be transferred into
+// an enterprise, and which of its members would be affected
+type TransferrableStatus struct {
+	Transferrable      bool     `json:"transferrable"`
+	NewBillableMembers []Member `json:"newBillableMembers"`
+	RestrictedMembers  []Member `json:"restrictedMembers"`
+}
+
+func (c *Client) Enterprise(enterpriseId string) (enterprise *Enterprise, err error) {
+	if err = c.getJSON("/enterprises/"+enterpriseId, &enterprise); err != nil {
+		return
+	}
+	enterprise.client = c
+	return
+}
+
+func (e *Enterprise) Members() (members []Member, err error) {
+	if err = e.client.getJSON("/enterprises/"+e.Id+"/members?fields=all", &members); err != nil {
+		return
+	}
+	for i := range members {
+		members[i].client = e.client
+	}
+	return
+}
+
+func (e *Enterprise) Organizations() (organizations []Organization, err error) {
+	if err = e.client.getJSON("/enterprises/"+e.Id+"/organizations", &organizations); err != nil {
+		return
+	}
+	for i := range organizations {
+		organizations[i].client = e.client
+	}
+	return
+}
+
+func (e *Enterprise) Admins() (members []Member, err error) {
+	if err = e.client.getJSON("/enterprises/"+e.Id+"/admins?fields=all", &members); err != nil {
+		return
+	}
+	for i := range members {
+		members[i].client = e.client
+	}
+	return
+}
+
+// AddAdmin will make the member an admin of the enterprise
+// https://developers.trello.com/reference#put-enterprises-id-admins-idmember
+func (e *Enterprise) AddAdmin(memberId string) error {
+	_, err := e.client.Put("/enterprises/"+e.Id+"/admins/"+memberId, nil)
+	return err
+}
+
+// RemoveAdmin will revoke the enterprise admin rights of the member
+// https://developers.trello.com/reference#delete-enterprises-id-admins-idmember
+func (e *Enterprise) RemoveAdmin(memberId string) error {
+	_, err := e.client.Delete("/enterprises/" + e.Id + "/admins/" + memberId)
+	return err
+}
+
+// SetMemberDeactivated will deactivate, or reactivate, the member in the enterprise
+// https://developers.trello.com/reference#put-enterprises-id-members-idmember-deactivated
+func (e *Enterprise) SetMemberDeactivated(memberId string, deactivated bool) error {
+	payload := url.Values{}
+	payload.Set("value", strconv.FormatBool(deactivated))
+
+	_, err := e.client.Put("/enterprises/"+e.Id+"/members/"+memberId+"/deactivated", payload)
+	return err
+}
+
+// TransferrableOrganization will tell whether the organization can be
+// transferred into the enterprise
+// https://developers.trello.com/reference#get-enterprises-id-transferrable-organization-idorganization
+func (e *Enterprise) TransferrableOrganization(orgId string) (*TransferrableStatus, error) {
+	status := &TransferrableStatus{}
+	if err := e.client.getJSON("/enterprises/"+e.Id+"/transferrable/organization/"+orgId, status); err != nil {
+		return nil, err
+	}
+	for i := range status.NewBillableMembers {
+		status.NewBillableMembers[i].client = e.client
+	}
+	for i := range status.RestrictedMembers {
+		status.RestrictedMembers[i].client = e.client
+	}
+	return status, nil
+}
+
+// TransferOrganization will transfer the organization into the enterprise
+// https://developers.trello.com/reference#put-enterprises-id-organizations
+func (e *Enterprise) TransferOrganization(orgId string) error {
+	payload := url.Values{}
+	payload.Set("idOrganization", orgId)
+
+	_, err := e.client.Put("/enterprises/"+e.Id+"/organizations", payload)
+	return err
+}
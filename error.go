@@ -0,0 +1,64 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Error is the error returned when the API responds with a status other than
+// 200. Trello gives the reason either as plain text, e.g. "invalid id", or as
+// a JSON object such as {"message":"invalid value for idList","error":"ERROR"}.
+type Error struct {
+	StatusCode int
+	Message    string // the reason given by Trello, empty if it was not found
+	Code       string // the "error" field of JSON bodies, e.g. "API_TOKEN_LIMIT_EXCEEDED"
+	Body       []byte // the raw body, e.g. to parse formats not handled here
+}
+
+func (e *Error) Error() string {
+	reason := e.Message
+	if reason == "" {
+		reason = string(e.Body)
+	}
+	return fmt.Sprintf("Received unexpected status %d while trying to retrieve the server data with \"%s\"", e.StatusCode, reason)
+}
+
+func newError(statusCode int, body []byte) *Error {
+	e := &Error{StatusCode: statusCode, Body: body}
+
+	text := strings.TrimSpace(string(body))
+	if strings.HasPrefix(text, "{") {
+		var parsed struct {
+			Message string `json:"message"`
+			Error   string `json:"error"`
+		}
+		if json.Unmarshal(body, &parsed) == nil {
+			e.Message = parsed.Message
+			e.Code = parsed.Error
+			if e.Message == "" {
+				e.Message = e.Code
+			}
+		}
+	} else if !strings.HasPrefix(text, "<") {
+		// plain text, unlike the HTML pages of proxies and gateways
+		e.Message = text
+	}
+	return e
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewError(t *testing.T) {
+	tests := []struct {
+		status  int
+		body    string
+		message string
+		code    string
+	}{
+		{400, `{"message":"invalid value for idList","error":"ERROR"}`, "invalid value for idList", "ERROR"},
+		{429, `{"error":"API_TOKEN_LIMIT_EXCEEDED","message":"Rate limit exceeded"}`, "Rate limit exceeded", "API_TOKEN_LIMIT_EXCEEDED"},
+		{400, `{"error":"ERROR"}`, "ERROR", "ERROR"},
+		{401, "unauthorized card permission requested\n", "unauthorized card permission requested", ""},
+		{502, "<html><body>Bad Gateway</body></html>", "", ""},
+		{500, `{"message":`, "", ""},
+	}
+	for _, test := range tests {
+		e := newError(test.status, []byte(test.body))
+		if e.StatusCode != test.status || e.Message != test.message || e.Code != test.code || string(e.Body) != test.body {
+			t.Errorf("newError(%d, %q) = %+v, want message %q and code %q", test.status, test.body, e, test.message, test.code)
+		}
+		reason := test.message
+		if reason == "" {
+			reason = test.body
+		}
+		if !strings.Contains(e.Error(), reason) {
+			t.Errorf("newError(%d, %q).Error() = %q, want it to contain %q", test.status, test.body, e.Error(), reason)
+		}
+	}
+}
+
+func TestClientError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid id", http.StatusNotFound)
+	})
+
+	_, err := client.Card("missing")
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Card returned %T %v, want *Error", err, err)
+	}
+	if e.StatusCode != http.StatusNotFound || e.Message != "invalid id" {
+		t.Errorf("Card returned %+v, want status 404 and message \"invalid id\"", e)
+	}
+}
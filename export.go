@@ -0,0 +1,119 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExportBoardsOpts configures Organization.ExportBoards
+type ExportBoardsOpts struct {
+	// Filter selects the boards to export, all of them when empty
+	Filter BoardFilter
+	// Workers is the number of boards exported at once, 4 by default. The
+	// rate limiter of the client still bounds the number of requests.
+	Workers int
+}
+
+// ExportBoards will write the export of every board of the organization, see
+// Board.Export, to dir/boards/<shortLink>.json, along with dir/organization.json
+// describing the organization and listing the exported boards. Boards are
+// exported concurrently; when some of them fail the others are still written
+// and the returned error lists the failures. When ctx is cancelled the boards
+// being exported are interrupted, no manifest is written and ctx.Err() is
+// returned.
+func (o *Organization) ExportBoards(ctx context.Context, dir string, opts ExportBoardsOpts) error {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 4
+	}
+	boards, err := o.FilteredBoards(opts.Filter, "name", "closed", "shortLink", "url")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Join(dir, "boards"), 0755); err != nil {
+		return err
+	}
+
+	ops := make([]BulkOp, len(boards))
+	for i := range boards {
+		board := &boards[i]
+		ops[i] = func() (interface{}, error) {
+			return nil, writeBoardExport(ctx, board, filepath.Join(dir, "boards", exportFileName(board)))
+		}
+	}
+	results := Bulk(ctx, workers, ops)
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	var failures []string
+	for i, result := range results {
+		if result.Err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%s): %v", boards[i].Name, boards[i].Id, result.Err))
+		}
+	}
+
+	manifest := struct {
+		Organization *Organization `json:"organization"`
+		Boards       []Board       `json:"boards"`
+	}{o, boards}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(dir, "organization.json"), data, 0644); err != nil {
+		return err
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("Failed to export %d of %d boards: %s", len(failures), len(boards), strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func exportFileName(board *Board) string {
+	if board.ShortLink != "" {
+		return board.ShortLink + ".json"
+	}
+	return board.Id + ".json"
+}
+
+// writeBoardExport will write the board export to a temporary file renamed
+// into place once complete, so that an interrupted backup leaves no truncated
+// export behind.
+func writeBoardExport(ctx context.Context, board *Board, path string) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err = board.ExportContext(ctx, f); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
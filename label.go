@@ -0,0 +1,87 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Label is a label of a board, which can be put on its cards
+// https://developers.trello.com/advanced-reference/label
+type Label struct {
+	Id      string `json:"id"`
+	IdBoard string `json:"idBoard"`
+	Name    string `json:"name"`
+	Color   string `json:"color"`
+	Uses    int    `json:"uses"`
+}
+
+// labelColors is the palette of label colors, each base color coming in a
+// default, a _light and a _dark shade. An empty color makes a label without
+// color.
+var labelColors = map[string]bool{}
+
+func init() {
+	for _, color := range []string{"green", "yellow", "orange", "red", "purple", "blue", "sky", "lime", "pink", "black"} {
+		labelColors[color] = true
+		labelColors[color+"_light"] = true
+		labelColors[color+"_dark"] = true
+	}
+}
+
+// labelColorValue will validate color and format it as a color parameter
+func labelColorValue(color string) (string, error) {
+	if color == "" {
+		return "null", nil
+	}
+	if !labelColors[color] {
+		return "", fmt.Errorf("Label color %q is invalid", color)
+	}
+	return color, nil
+}
+
+func (b *Board) Labels() (labels []Label, err error) {
+	err = b.client.getJSON("/boards/"+b.Id+"/labels", &labels)
+	return
+}
+
+// CreateLabel will create a new label on the board. color is one of green,
+// yellow, orange, red, purple, blue, sky, lime, pink or black, optionally
+// suffixed with _light or _dark, or empty for a label without color.
+// https://developers.trello.com/advanced-reference/board#post-1-boards-board-id-labels
+func (b *Board) CreateLabel(name, color string) (*Label, error) {
+	colorValue, err := labelColorValue(color)
+	if err != nil {
+		return nil, err
+	}
+	payload := url.Values{}
+	payload.Set("name", name)
+	payload.Set("color", colorValue)
+
+	body, err := b.client.Post("/boards/"+b.Id+"/labels", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	label := &Label{}
+	if err = json.Unmarshal(body, label); err != nil {
+		return nil, err
+	}
+	return label, nil
+}
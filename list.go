@@ -18,6 +18,12 @@ package trello
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type List struct {
@@ -26,27 +32,42 @@ type List struct {
 	Name    string  `json:"name"`
 	Closed  bool    `json:"closed"`
 	IdBoard string  `json:"idBoard"`
-	Pos     float32 `json:"pos"`
+	Pos     float64 `json:"pos"`
+}
+
+// CardSort is the key List.SortCards orders the cards by
+type CardSort string
+
+const (
+	CardSortDue     CardSort = "due"
+	CardSortName    CardSort = "name"
+	CardSortCreated CardSort = "created"
+)
+
+// AddCardOpts are the fields of a card created with List.AddCard
+type AddCardOpts struct {
+	Name string
+	Desc string
+	// Position can take the values "top", "bottom" or a positive number,
+	// see PositionBetween to insert the card between two others
+	Position interface{}
+	Due      *time.Time
+	Labels   []string // label ids
+	Members  []string // member ids
 }
 
 func (c *Client) List(listId string) (list *List, err error) {
-	body, err := c.Get("/lists/" + listId)
-	if err != nil {
+	if err = c.getJSON("/lists/"+listId, &list); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &list)
 	list.client = c
 	return
 }
 
 func (l *List) Cards() (cards []Card, err error) {
-	body, err := l.client.Get("/lists/" + l.Id + "/cards")
-	if err != nil {
+	if err = l.client.getJSON("/lists/"+l.Id+"/cards", &cards); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &cards)
 	for i := range cards {
 		cards[i].client = l.client
 	}
@@ -59,14 +80,211 @@ func (l *List) Actions(beforeId string) (actions []Action, err error) {
 		suffix = "?before=" + beforeId
 	}
 
-	body, err := l.client.Get("/lists/" + l.Id + "/actions" + suffix)
-	if err != nil {
+	if err = l.client.getJSON("/lists/"+l.Id+"/actions"+suffix, &actions); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &actions)
 	for i := range actions {
 		actions[i].client = l.client
 	}
 	return
 }
+
+// ArchiveAllCards will archive all the cards of the list
+// https://developers.trello.com/advanced-reference/list#post-1-lists-idlist-archiveallcards
+func (l *List) ArchiveAllCards() error {
+	_, err := l.client.Post("/lists/"+l.Id+"/archiveAllCards", nil)
+	return err
+}
+
+// MoveAllCards will move all the cards of the list to another list, which may
+// belong to another board
+// https://developers.trello.com/advanced-reference/list#post-1-lists-idlist-moveallcards
+func (l *List) MoveAllCards(boardId, listId string) error {
+	payload := url.Values{}
+	payload.Set("idBoard", boardId)
+	payload.Set("idList", listId)
+
+	_, err := l.client.Post("/lists/"+l.Id+"/moveAllCards", payload)
+	return err
+}
+
+// SortCards will reorder the cards of the list by due date (cards without one
+// last), name or creation date. Trello has no endpoint sorting a list, so the
+// cards are sorted locally and only the cards which are out of order are given
+// a new position, one request per moved card.
+func (l *List) SortCards(by CardSort) error {
+	cards, err := l.Cards()
+	if err != nil {
+		return err
+	}
+
+	var less func(a, b *Card) bool
+	switch by {
+	case CardSortDue:
+		less = func(a, b *Card) bool {
+			if a.Due.IsZero() || b.Due.IsZero() {
+				return !a.Due.IsZero()
+			}
+			return a.Due.Before(b.Due.Time)
+		}
+	case CardSortName:
+		less = func(a, b *Card) bool {
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		}
+	case CardSortCreated:
+		// ids start with the hex encoded creation timestamp
+		less = func(a, b *Card) bool {
+			return a.Id < b.Id
+		}
+	default:
+		return fmt.Errorf("Card sort %q is invalid. Only 'due', 'name' or 'created'", by)
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		return less(&cards[i], &cards[j])
+	})
+
+	current := make([]float64, len(cards))
+	for i := range cards {
+		current[i] = cards[i].Pos
+	}
+	for i, pos := range sortedPositions(current) {
+		if pos == current[i] {
+			continue
+		}
+		payload := url.Values{}
+		payload.Set("value", strconv.FormatFloat(pos, 'f', -1, 64))
+		if _, err := l.client.Put("/cards/"+cards[i].Id+"/pos", payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedPositions returns increasing positions for items currently at the
+// given positions, keeping the longest run of items already in order where
+// they are so that as few items as possible have to move.
+func sortedPositions(current []float64) []float64 {
+	n := len(current)
+	// longest strictly increasing subsequence, tails[k] being the index of the
+	// smallest tail of the subsequences of length k+1
+	tails := []int{}
+	prev := make([]int, n)
+	for i, pos := range current {
+		k := sort.Search(len(tails), func(k int) bool {
+			return current[tails[k]] >= pos
+		})
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+	keep := make([]bool, n)
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			keep[i] = true
+		}
+	}
+
+	positions := make([]float64, n)
+	for i := 0; i < n; {
+		if keep[i] {
+			positions[i] = current[i]
+			i++
+			continue
+		}
+		// spread the run of moved items evenly up to the next kept item
+		j := i
+		for j < n && !keep[j] {
+			j++
+		}
+		low := 0.0
+		if i > 0 {
+			low = positions[i-1]
+		}
+		high := low + float64(j-i+1)*65536
+		if j < n {
+			high = current[j]
+		}
+		step := (high - low) / float64(j-i+1)
+		if step < 1e-6 {
+			// no room left between the neighbours, renumber everything
+			for k := range positions {
+				positions[k] = float64(k+1) * 65536
+			}
+			return positions
+		}
+		for k := i; k < j; k++ {
+			positions[k] = low + float64(k-i+1)*step
+		}
+		i = j
+	}
+	return positions
+}
+
+// AddCard will create a new card in the list
+// https://developers.trello.com/advanced-reference/card#post-1-cards
+func (l *List) AddCard(opts AddCardOpts) (*Card, error) {
+	payload := url.Values{}
+	payload.Set("idList", l.Id)
+	payload.Set("name", opts.Name)
+	if opts.Desc != "" {
+		payload.Set("desc", opts.Desc)
+	}
+	if opts.Position != nil {
+		pos, err := formatPosition(opts.Position)
+		if err != nil {
+			return nil, err
+		}
+		payload.Set("pos", pos)
+	}
+	if opts.Due != nil {
+		payload.Set("due", opts.Due.Format(time.RFC3339))
+	}
+	if len(opts.Labels) > 0 {
+		payload.Set("idLabels", strings.Join(opts.Labels, ","))
+	}
+	if len(opts.Members) > 0 {
+		payload.Set("idMembers", strings.Join(opts.Members, ","))
+	}
+
+	body, err := l.client.Post("/cards", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	newCard := &Card{}
+	if err = json.Unmarshal(body, newCard); err != nil {
+		return nil, err
+	}
+	newCard.client = l.client
+	return newCard, nil
+}
+
+// SetPosition will move the list within its board. pos can take the values
+// "top", "bottom" or a positive number.
+// https://developers.trello.com/advanced-reference/list#put-1-lists-idlist-pos
+func (l *List) SetPosition(pos interface{}) (*List, error) {
+	position, err := formatPosition(pos)
+	if err != nil {
+		return nil, err
+	}
+	payload := url.Values{}
+	payload.Set("value", position)
+
+	body, err := l.client.Put("/lists/"+l.Id+"/pos", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	newList := &List{}
+	if err = json.Unmarshal(body, newList); err != nil {
+		return nil, err
+	}
+	newList.client = l.client
+	return newList, nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortedPositions(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []float64
+		want    []float64
+	}{
+		{"empty", []float64{}, []float64{}},
+		{"already sorted", []float64{1, 2, 3}, []float64{1, 2, 3}},
+		{"first item moved", []float64{3, 1, 2}, []float64{0.5, 1, 2}},
+		{"middle item moved", []float64{65536, 196608, 131072}, []float64{65536, 98304, 131072}},
+		{"last item moved", []float64{2, 3, 1}, []float64{2, 3, 65539}},
+		{"run of items spread", []float64{9, 8, 4}, []float64{4.0 / 3, 2 * (4.0 / 3), 4}},
+		{"equal positions", []float64{1, 1, 1}, []float64{1.0 / 3, 2 * (1.0 / 3), 1}},
+		{"no room left", []float64{5, 1e-6, 2e-6}, []float64{65536, 131072, 196608}},
+	}
+	for _, test := range tests {
+		got := sortedPositions(test.current)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: sortedPositions(%v) = %v, want %v", test.name, test.current, got, test.want)
+		}
+		for i := 1; i < len(got); i++ {
+			if got[i] <= got[i-1] {
+				t.Errorf("%s: sortedPositions(%v) = %v is not increasing", test.name, test.current, got)
+				break
+			}
+		}
+	}
+}
@@ -18,6 +18,8 @@ package trello
 
 import (
 	"encoding/json"
+	"io"
+	"net/url"
 	"strings"
 )
 
@@ -59,50 +61,298 @@ type Member struct {
 	PremiumFeatures    []string `json:"premiumFeatures"`
 }
 
+// BoardStar is a board starred by a member
+// https://developers.trello.com/advanced-reference/member#get-1-members-idmember-or-username-boardstars
+type BoardStar struct {
+	client   *Client
+	memberID string  // back pointer to the member Id
+	Id       string  `json:"id"`
+	IdBoard  string  `json:"idBoard"`
+	Pos      float64 `json:"pos"`
+}
+
+// MemberBoardBackground is a background image uploaded by a member, which can
+// be used on the boards of the member
+// https://developers.trello.com/advanced-reference/member#get-1-members-idmember-or-username-boardbackgrounds
+type MemberBoardBackground struct {
+	client      *Client
+	memberID    string            // back pointer to the member Id
+	Id          string            `json:"id"`
+	Type        string            `json:"type"`
+	Brightness  string            `json:"brightness"`
+	FullSizeUrl string            `json:"fullSizeUrl"`
+	Scaled      []BoardBackground `json:"scaled"`
+	Tile        bool              `json:"tile"`
+}
+
 func (c *Client) Member(nick string) (member *Member, err error) {
-	body, err := c.Get("/members/" + nick)
-	if err != nil {
+	if err = c.getJSON("/members/"+nick, &member); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &member)
 	member.client = c
 	return
 }
 
+// MeOpts selects the fields and nested resources returned by Client.Me
+type MeOpts struct {
+	// Fields of the member, all of them when empty
+	Fields []string
+	// Boards includes the boards of the member matching the filter
+	Boards      BoardFilter
+	BoardFields []string
+	// Organizations includes the organizations of the member: all, members,
+	// public or none
+	Organizations string
+	// Notifications includes the notifications of the member matching the
+	// filter: all, or a comma separated list of notification types
+	Notifications string
+}
+
+// Me is the member owning the client token, along with the nested resources
+// selected with MeOpts. Their fields are prefixed with Nested so that the
+// methods of Member, such as Boards, stay available.
+type Me struct {
+	Member
+	NestedBoards        []Board        `json:"boards"`
+	NestedOrganizations []Organization `json:"organizations"`
+	NestedNotifications []Notification `json:"notifications"`
+}
+
+// Me will return the member owning the client token, with the boards,
+// organizations and notifications selected in opts fetched in the same call
+// https://developers.trello.com/advanced-reference/member#get-1-members-idmember-or-username
+func (c *Client) Me(opts MeOpts) (*Me, error) {
+	params := url.Values{}
+	if len(opts.Fields) == 0 {
+		params.Set("fields", "all")
+	} else {
+		params.Set("fields", strings.Join(opts.Fields, ","))
+	}
+	if opts.Boards != "" {
+		params.Set("boards", string(opts.Boards))
+		if len(opts.BoardFields) == 0 {
+			params.Set("board_fields", "all")
+		} else {
+			params.Set("board_fields", strings.Join(opts.BoardFields, ","))
+		}
+	}
+	if opts.Organizations != "" {
+		params.Set("organizations", opts.Organizations)
+		params.Set("organization_fields", "all")
+	}
+	if opts.Notifications != "" {
+		params.Set("notifications", opts.Notifications)
+	}
+
+	me := &Me{}
+	if err := c.getJSON("/members/me?"+params.Encode(), me); err != nil {
+		return nil, err
+	}
+	me.client = c
+	for i := range me.NestedBoards {
+		me.NestedBoards[i].client = c
+	}
+	for i := range me.NestedOrganizations {
+		me.NestedOrganizations[i].client = c
+	}
+	for i := range me.NestedNotifications {
+		me.NestedNotifications[i].client = c
+	}
+	return me, nil
+}
+
 func (m *Member) Boards(field ...string) (boards []Board, err error) {
-	fields := ""
+	return m.FilteredBoards("", field...)
+}
+
+// FilteredBoards will return the boards of the member matching filter, with
+// only the given fields set. An empty filter returns all the boards and no
+// fields returns all the fields.
+// https://developers.trello.com/advanced-reference/member#get-1-members-idmember-or-username-boards
+func (m *Member) FilteredBoards(filter BoardFilter, field ...string) (boards []Board, err error) {
+	return m.client.filteredBoards("/members/"+m.Id+"/boards", filter, field)
+}
+
+// MyBoards will return the boards of the member owning the client token
+// https://developers.trello.com/advanced-reference/member#get-1-members-idmember-or-username-boards
+func (c *Client) MyBoards(field ...string) (boards []Board, err error) {
+	return c.filteredBoards("/members/me/boards", "", field)
+}
+
+// filteredBoards will return the boards listed by resource, e.g.
+// "/members/me/boards", matching filter and with only the given fields set
+func (c *Client) filteredBoards(resource string, filter BoardFilter, field []string) (boards []Board, err error) {
+	params := url.Values{}
 	if len(field) == 0 {
-		fields = "all"
+		params.Set("fields", "all")
 	} else {
-		fields = strings.Join(field, ",")
+		params.Set("fields", strings.Join(field, ","))
+	}
+	if filter != "" {
+		params.Set("filter", string(filter))
 	}
 
-	body, err := m.client.Get("/members/" + m.Id + "/boards?fields=" + fields)
-	if err != nil {
+	if err = c.getJSON(resource+"?"+params.Encode(), &boards); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &boards)
 	for i := range boards {
-		boards[i].client = m.client
+		boards[i].client = c
 	}
 	return
 }
 
 func (m *Member) Notifications() (notifications []Notification, err error) {
-	body, err := m.client.Get("/members/" + m.Id + "/notifications")
-	if err != nil {
+	if err = m.client.getJSON("/members/"+m.Id+"/notifications", &notifications); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &notifications)
 	for i := range notifications {
 		notifications[i].client = m.client
 	}
 	return
 }
 
+// Update will change the profile of the member. Empty values are left unchanged.
+// https://developers.trello.com/advanced-reference/member#put-1-members-idmember-or-username
+func (m *Member) Update(fullName, initials, bio, username string) (*Member, error) {
+	payload := url.Values{}
+	if fullName != "" {
+		payload.Set("fullName", fullName)
+	}
+	if initials != "" {
+		payload.Set("initials", initials)
+	}
+	if bio != "" {
+		payload.Set("bio", bio)
+	}
+	if username != "" {
+		payload.Set("username", username)
+	}
+
+	body, err := m.client.Put("/members/"+m.Id, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	newMember := &Member{}
+	if err = json.Unmarshal(body, newMember); err != nil {
+		return nil, err
+	}
+	newMember.client = m.client
+	return newMember, nil
+}
+
+// SetAvatar will upload the image read from r as the avatar of the member
+// https://developers.trello.com/advanced-reference/member#post-1-members-idmember-or-username-avatar
+func (m *Member) SetAvatar(r io.Reader) error {
+	_, err := m.client.PostFile("/members/"+m.Id+"/avatar", nil, "file", "avatar", r)
+	return err
+}
+
+func (m *Member) BoardStars() (stars []BoardStar, err error) {
+	if err = m.client.getJSON("/members/"+m.Id+"/boardStars", &stars); err != nil {
+		return
+	}
+	for i := range stars {
+		stars[i].client = m.client
+		stars[i].memberID = m.Id
+	}
+	return
+}
+
+// StarBoard will star the board for the member.
+// pos can take the values 'top', 'bottom', or a positive number
+// https://developers.trello.com/advanced-reference/member#post-1-members-idmember-or-username-boardstars
+func (m *Member) StarBoard(boardId string, pos interface{}) (*BoardStar, error) {
+	position, err := formatPosition(pos)
+	if err != nil {
+		return nil, err
+	}
+	payload := url.Values{}
+	payload.Set("idBoard", boardId)
+	payload.Set("pos", position)
+
+	body, err := m.client.Post("/members/"+m.Id+"/boardStars", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	star := &BoardStar{}
+	if err = json.Unmarshal(body, star); err != nil {
+		return nil, err
+	}
+	star.client = m.client
+	star.memberID = m.Id
+	return star, nil
+}
+
+// Move will move the star to another position in the starred boards of the member.
+// pos can take the values 'top', 'bottom', or a positive number
+// https://developers.trello.com/advanced-reference/member#put-1-members-idmember-or-username-boardstars-idboardstar
+func (s *BoardStar) Move(pos interface{}) (*BoardStar, error) {
+	position, err := formatPosition(pos)
+	if err != nil {
+		return nil, err
+	}
+	payload := url.Values{}
+	payload.Set("pos", position)
+
+	body, err := s.client.Put("/members/"+s.memberID+"/boardStars/"+s.Id, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	star := &BoardStar{}
+	if err = json.Unmarshal(body, star); err != nil {
+		return nil, err
+	}
+	star.client = s.client
+	star.memberID = s.memberID
+	return star, nil
+}
+
+// Delete will unstar the board
+// https://developers.trello.com/advanced-reference/member#delete-1-members-idmember-or-username-boardstars-idboardstar
+func (s *BoardStar) Delete() error {
+	_, err := s.client.Delete("/members/" + s.memberID + "/boardStars/" + s.Id)
+	return err
+}
+
+func (m *Member) BoardBackgrounds() (backgrounds []MemberBoardBackground, err error) {
+	if err = m.client.getJSON("/members/"+m.Id+"/boardBackgrounds", &backgrounds); err != nil {
+		return
+	}
+	for i := range backgrounds {
+		backgrounds[i].client = m.client
+		backgrounds[i].memberID = m.Id
+	}
+	return
+}
+
+// UploadBoardBackground will upload the image read from r as a new board
+// background of the member
+// https://developers.trello.com/advanced-reference/member#post-1-members-idmember-or-username-boardbackgrounds
+func (m *Member) UploadBoardBackground(r io.Reader) (*MemberBoardBackground, error) {
+	body, err := m.client.PostFile("/members/"+m.Id+"/boardBackgrounds", nil, "file", "background", r)
+	if err != nil {
+		return nil, err
+	}
+
+	background := &MemberBoardBackground{}
+	if err = json.Unmarshal(body, background); err != nil {
+		return nil, err
+	}
+	background.client = m.client
+	background.memberID = m.Id
+	return background, nil
+}
+
+// Delete will delete the board background
+// https://developers.trello.com/advanced-reference/member#delete-1-members-idmember-or-username-boardbackgrounds-idboardbackground
+func (b *MemberBoardBackground) Delete() error {
+	_, err := b.client.Delete("/members/" + b.memberID + "/boardBackgrounds/" + b.Id)
+	return err
+}
+
 // TODO: Avatar sizes [170, 30]
 func (m *Member) AvatarUrl() string {
 	return "https://trello-avatars.s3.amazonaws.com/" + m.AvatarHash + "/170.png"
@@ -16,14 +16,12 @@ limitations under the License.
 
 package trello
 
-import "encoding/json"
-
 type Notification struct {
 	client *Client
 	Id     string `json:"id"`
 	Unread bool   `json:"unread"`
 	Type   string `json:"type"`
-	Date   string `json:"date"`
+	Date   Time   `json:"date"`
 	Data   struct {
 		ListBefore struct {
 			Id   string `json:"id"`
@@ -59,12 +57,9 @@ type Notification struct {
 }
 
 func (c *Client) Notification(notificationId string) (notification *Notification, err error) {
-	body, err := c.Get("/notifications/" + notificationId)
-	if err != nil {
+	if err = c.getJSON("/notifications/"+notificationId, &notification); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &notification)
 	notification.client = c
 	return
 }
@@ -0,0 +1,106 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created with NewClient
+type Option func(c *Client) error
+
+// WithBaseURL will send the API calls to baseURL instead of
+// https://api.trello.com, e.g. to go through a proxy. The API version is
+// appended to it.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) error {
+		return c.SetBaseURL(baseURL)
+	}
+}
+
+// WithAPIVersion will use another version of the API than 1
+func WithAPIVersion(version string) Option {
+	return func(c *Client) error {
+		c.version = version
+		return nil
+	}
+}
+
+// WithUserAgent will set the User-Agent header of the API calls
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithHTTPClient will send the API calls with client instead of
+// http.DefaultClient. The client is not modified.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) error {
+		if client == nil {
+			return fmt.Errorf("HTTP client is nil")
+		}
+		c.client = client
+		return nil
+	}
+}
+
+// WithRetry will retry the calls rejected by the rate limits of Trello, and
+// the idempotent calls failing with a server or network error, up to
+// maxRetries times. The delay before a retry starts at backoff and doubles
+// with each attempt.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) error {
+		c.retries = maxRetries
+		c.retryBackoff = backoff
+		return nil
+	}
+}
+
+// WithRateLimit will replace the default rate limiter of the client, see
+// Client.SetRateLimiter
+func WithRateLimit(limiter Limiter) Option {
+	return func(c *Client) error {
+		c.limiter = limiter
+		return nil
+	}
+}
+
+// WithDryRun will record the mutating calls of the client in d instead of
+// sending them, see Client.SetDryRun
+func WithDryRun(d *DryRun) Option {
+	return func(c *Client) error {
+		c.dryRun = d
+		return nil
+	}
+}
+
+// WithMaxResponseBytes will make the calls fail with ErrResponseTooLarge when
+// the response body exceeds n bytes, e.g. to guard against huge boards. No
+// limit applies when n is 0, the default.
+func WithMaxResponseBytes(n int64) Option {
+	return func(c *Client) error {
+		if n < 0 {
+			return fmt.Errorf("Maximum response size %d is invalid", n)
+		}
+		c.maxResponseBytes = n
+		return nil
+	}
+}
@@ -17,41 +17,39 @@ limitations under the License.
 package trello
 
 import (
-	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
 )
 
 type Organization struct {
 	client      *Client
-	Id          string   `json:"id"`
-	Name        string   `json:"name"`
-	DisplayName string   `json:"displayName"`
-	Desc        string   `json:"desc"`
-	DescData    string   `json:"descData"`
-	Url         string   `json:"url"`
-	Website     string   `json:"website"`
-	LogoHash    string   `json:"logoHash"`
-	Products    []string `json:"products"`
-	PowerUps    []string `json:"powerUps"`
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Desc        string `json:"desc"`
+	DescData    struct {
+		Emoji struct{} `json:"emoji"`
+	} `json:"descData"`
+	Url      string `json:"url"`
+	Website  string `json:"website"`
+	LogoHash string `json:"logoHash"`
+	Products []int  `json:"products"`
+	PowerUps []int  `json:"powerUps"`
 }
 
 func (c *Client) Organization(orgId string) (organization *Organization, err error) {
-	body, err := c.Get("/organization/" + orgId)
-	if err != nil {
+	if err = c.getJSON("/organization/"+orgId, &organization); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &organization)
 	organization.client = c
 	return
 }
 
 func (o *Organization) Members() (members []Member, err error) {
-	body, err := o.client.Get("/organization/" + o.Id + "/members?fields=all")
-	if err != nil {
+	if err = o.client.getJSON("/organization/"+o.Id+"/members?fields=all", &members); err != nil {
 		return
 	}
-
-	err = json.Unmarshal(body, &members)
 	for i := range members {
 		members[i].client = o.client
 	}
@@ -59,14 +57,86 @@ func (o *Organization) Members() (members []Member, err error) {
 }
 
 func (o *Organization) Boards() (boards []Board, err error) {
-	body, err := o.client.Get("/organizations/" + o.Id + "/boards")
-	if err != nil {
-		return
+	return o.FilteredBoards("")
+}
+
+// FilteredBoards will return the boards of the organization matching filter,
+// with only the given fields set. An empty filter returns all the boards and
+// no fields returns all the fields. BoardFilterStarred is not supported for
+// organizations.
+// https://developers.trello.com/advanced-reference/organization#get-1-organizations-idorg-or-name-boards
+func (o *Organization) FilteredBoards(filter BoardFilter, field ...string) (boards []Board, err error) {
+	if filter == BoardFilterStarred {
+		return nil, fmt.Errorf("Board filter %q is not supported for organizations", filter)
+	}
+	return o.client.filteredBoards("/organizations/"+o.Id+"/boards", filter, field)
+}
+
+// AddMember will add an existing member to the organization, by member id or
+// username. role is one of admin or normal.
+// https://developers.trello.com/advanced-reference/organization#put-1-organizations-idorg-or-name-members-idmember
+func (o *Organization) AddMember(memberId, role string) error {
+	if err := validateOrganizationRole(role); err != nil {
+		return err
 	}
+	payload := url.Values{}
+	payload.Set("type", role)
+
+	_, err := o.client.Put("/organizations/"+o.Id+"/members/"+memberId, payload)
+	return err
+}
 
-	err = json.Unmarshal(body, &boards)
-	for i := range boards {
-		boards[i].client = o.client
+// InviteMemberByEmail will invite someone to the organization by email,
+// whether or not they already have a Trello account. fullName is shown to the
+// invitee until they change it. role is one of admin or normal.
+// https://developers.trello.com/advanced-reference/organization#put-1-organizations-idorg-or-name-members
+func (o *Organization) InviteMemberByEmail(email, fullName, role string) error {
+	if err := validateOrganizationRole(role); err != nil {
+		return err
 	}
-	return
+	payload := url.Values{}
+	payload.Set("email", email)
+	payload.Set("fullName", fullName)
+	payload.Set("type", role)
+
+	_, err := o.client.Put("/organizations/"+o.Id+"/members", payload)
+	return err
+}
+
+// RemoveMember will remove the member from the organization
+// https://developers.trello.com/advanced-reference/organization#delete-1-organizations-idorg-or-name-members-idmember
+func (o *Organization) RemoveMember(memberId string) error {
+	_, err := o.client.Delete("/organizations/" + o.Id + "/members/" + memberId)
+	return err
+}
+
+// UpdateMemberRole will change the role of the member in the organization,
+// either admin or normal
+// https://developers.trello.com/advanced-reference/organization#put-1-organizations-idorg-or-name-members-idmember
+func (o *Organization) UpdateMemberRole(memberId, role string) error {
+	if err := validateOrganizationRole(role); err != nil {
+		return err
+	}
+	payload := url.Values{}
+	payload.Set("type", role)
+
+	_, err := o.client.Put("/organizations/"+o.Id+"/members/"+memberId, payload)
+	return err
+}
+
+// SetMemberDeactivated will deactivate, or reactivate, the member in the organization
+// https://developers.trello.com/advanced-reference/organization#put-1-organizations-idorg-or-name-members-idmember-deactivated
+func (o *Organization) SetMemberDeactivated(memberId string, deactivated bool) error {
+	payload := url.Values{}
+	payload.Set("value", strconv.FormatBool(deactivated))
+
+	_, err := o.client.Put("/organizations/"+o.Id+"/members/"+memberId+"/deactivated", payload)
+	return err
+}
+
+func validateOrganizationRole(role string) error {
+	if role != "admin" && role != "normal" {
+		return fmt.Errorf("Organization member role %q is invalid. Only 'admin' or 'normal'", role)
+	}
+	return nil
 }
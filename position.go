@@ -0,0 +1,61 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// PositionBetween will return a position placing an item between two
+// siblings at positions before and after. Use 0 for before when inserting
+// first and 0 for after when inserting last.
+func PositionBetween(before, after float64) float64 {
+	if after <= 0 {
+		return before + 65536
+	}
+	return before + (after-before)/2
+}
+
+// formatPosition will validate pos and format it as a pos parameter. pos can
+// take the values "top", "bottom", a positive number or its string form.
+func formatPosition(pos interface{}) (string, error) {
+	var f float64
+	switch p := pos.(type) {
+	case string:
+		if p == "top" || p == "bottom" {
+			return p, nil
+		}
+		var err error
+		if f, err = strconv.ParseFloat(p, 64); err != nil {
+			return "", fmt.Errorf("Position %q is invalid. Only 'top', 'bottom', or a positive number", p)
+		}
+	case float64:
+		f = p
+	case float32:
+		f = float64(p)
+	case int:
+		f = float64(p)
+	default:
+		return "", fmt.Errorf("Position %v of type %T is invalid. Only 'top', 'bottom', or a positive number", pos, pos)
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) || f <= 0 {
+		return "", fmt.Errorf("Position %v is invalid. Only 'top', 'bottom', or a positive number", pos)
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64), nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Trello allows 300 requests per 10 seconds for each API key and 100 requests
+// per 10 seconds for each token
+// https://developers.trello.com/get-started/limits
+const (
+	KeyRateLimit   = 300
+	TokenRateLimit = 100
+	RatePeriod     = 10 * time.Second
+)
+
+// Limiter is waited on by a Client before each request it sends
+type Limiter interface {
+	Wait()
+}
+
+// RateLimiter makes sure that no more than limit requests are sent in any
+// period long window. It is safe for concurrent use, and may be shared by
+// several clients.
+type RateLimiter struct {
+	mu     sync.Mutex
+	period time.Duration
+	sent   []time.Time // ring of the send times of the last limit requests
+	next   int         // oldest entry of sent
+}
+
+// NewRateLimiter will return a limiter allowing limit requests per period,
+// e.g. NewRateLimiter(TokenRateLimit, RatePeriod)
+func NewRateLimiter(limit int, period time.Duration) (*RateLimiter, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("Rate limit %d is invalid. It must be positive", limit)
+	}
+	if period <= 0 {
+		return nil, fmt.Errorf("Rate period %v is invalid. It must be positive", period)
+	}
+	return newRateLimiter(limit, period), nil
+}
+
+func newRateLimiter(limit int, period time.Duration) *RateLimiter {
+	return &RateLimiter{
+		period: period,
+		sent:   make([]time.Time, limit),
+	}
+}
+
+// Wait blocks until a request can be sent without exceeding the limit.
+func (l *RateLimiter) Wait() {
+	limiters{l}.Wait()
+}
+
+// delay returns how long to wait from now before the next request. The lock
+// must be held.
+func (l *RateLimiter) delay(now time.Time) time.Duration {
+	oldest := l.sent[l.next]
+	if oldest.IsZero() {
+		return 0
+	}
+	return oldest.Add(l.period).Sub(now)
+}
+
+// record takes the slot of a request sent at now. The lock must be held.
+func (l *RateLimiter) record(now time.Time) {
+	l.sent[l.next] = now
+	l.next = (l.next + 1) % len(l.sent)
+}
+
+// SharedRateLimiter hands out limiters to clients using the same API key with
+// different tokens, so that together they respect both the per key and the
+// per token limits.
+//
+//	shared := trello.NewSharedRateLimiter()
+//	for _, token := range tokens {
+//		token := token // each client keeps a pointer to its token
+//		client, _ := trello.NewAuthClient(key, &token)
+//		client.SetRateLimiter(shared.For(key, token))
+//	}
+type SharedRateLimiter struct {
+	mu     sync.Mutex
+	keys   map[string]*RateLimiter
+	tokens map[string]*RateLimiter
+}
+
+// NewSharedRateLimiter will return an empty SharedRateLimiter
+func NewSharedRateLimiter() *SharedRateLimiter {
+	return &SharedRateLimiter{
+		keys:   map[string]*RateLimiter{},
+		tokens: map[string]*RateLimiter{},
+	}
+}
+
+// For will return the limiter of a client using the given API key and token
+func (s *SharedRateLimiter) For(key, token string) Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keyLimiter, ok := s.keys[key]
+	if !ok {
+		keyLimiter = newRateLimiter(KeyRateLimit, RatePeriod)
+		s.keys[key] = keyLimiter
+	}
+	tokenLimiter, ok := s.tokens[token]
+	if !ok {
+		tokenLimiter = newRateLimiter(TokenRateLimit, RatePeriod)
+		s.tokens[token] = tokenLimiter
+	}
+	return limiters{tokenLimiter, keyLimiter}
+}
+
+// limiters reserves a slot in all of its limiters at once, so that each of
+// them records the time the request is actually sent. They are always locked
+// in the same order, the token limiter before the key limiter.
+type limiters []*RateLimiter
+
+func (ls limiters) Wait() {
+	for {
+		for _, l := range ls {
+			l.mu.Lock()
+		}
+		now := time.Now()
+		var delay time.Duration
+		for _, l := range ls {
+			if d := l.delay(now); d > delay {
+				delay = d
+			}
+		}
+		if delay <= 0 {
+			for _, l := range ls {
+				l.record(now)
+			}
+		}
+		for _, l := range ls {
+			l.mu.Unlock()
+		}
+		if delay <= 0 {
+			return
+		}
+		time.Sleep(delay)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// Reaction is an emoji reaction left by a member on a comment action
+// https://developers.trello.com/reference#actionsidactionreactions
+type Reaction struct {
+	client   *Client
+	actionID string // back pointer to the parent Id
+	Id       string `json:"id"`
+	IdMember string `json:"idMember"`
+	IdModel  string `json:"idModel"`
+	IdEmoji  string `json:"idEmoji"`
+	Member   struct {
+		Id         string `json:"id"`
+		AvatarHash string `json:"avatarHash"`
+		FullName   string `json:"fullName"`
+		Initials   string `json:"initials"`
+		Username   string `json:"username"`
+	} `json:"member"`
+	Emoji ReactionEmoji `json:"emoji"`
+}
+
+type ReactionEmoji struct {
+	Unified       string `json:"unified"`
+	Native        string `json:"native"`
+	Name          string `json:"name"`
+	SkinVariation string `json:"skinVariation"`
+	ShortName     string `json:"shortName"`
+}
+
+// ReactionSummary counts the reactions of one emoji on an action
+type ReactionSummary struct {
+	Id         string        `json:"id"`
+	IdEmoji    string        `json:"idEmoji"`
+	IdReaction string        `json:"idReaction"`
+	Count      int           `json:"count"`
+	Emoji      ReactionEmoji `json:"emoji"`
+}
+
+// Reactions will return the reactions on the action
+// https://developers.trello.com/reference#actionsidactionreactions
+func (a *Action) Reactions() (reactions []Reaction, err error) {
+	if err = a.client.getJSON("/actions/"+a.Id+"/reactions?member=true&emoji=true", &reactions); err != nil {
+		return
+	}
+	for i := range reactions {
+		reactions[i].client = a.client
+		reactions[i].actionID = a.Id
+	}
+	return
+}
+
+// AddReaction will react to the action with the emoji of the given short
+// name, e.g. "thumbsup"
+// https://developers.trello.com/reference#actionsidactionreactions-1
+func (a *Action) AddReaction(emoji string) (*Reaction, error) {
+	payload := url.Values{}
+	payload.Set("shortName", emoji)
+
+	body, err := a.client.Post("/actions/"+a.Id+"/reactions", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := &Reaction{}
+	if err = json.Unmarshal(body, reaction); err != nil {
+		return nil, err
+	}
+	reaction.client = a.client
+	reaction.actionID = a.Id
+	return reaction, nil
+}
+
+// ReactionsSummary will return the number of reactions on the action per emoji
+// https://developers.trello.com/reference#reactionssummary
+func (a *Action) ReactionsSummary() (summary []ReactionSummary, err error) {
+	err = a.client.getJSON("/actions/"+a.Id+"/reactionsSummary", &summary)
+	return
+}
+
+// Delete will remove the reaction from its action
+// https://developers.trello.com/reference#actionsidactionreactionsid-1
+func (r *Reaction) Delete() error {
+	_, err := r.client.Delete("/actions/" + r.actionID + "/reactions/" + r.Id)
+	return err
+}
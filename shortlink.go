@@ -0,0 +1,87 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var shortLinkPattern = regexp.MustCompile(`^[0-9A-Za-z]{8}$`)
+
+// ParseCardURL will extract the shortLink from a card URL such as
+// https://trello.com/c/8sB7wile/12-a-card
+func ParseCardURL(cardURL string) (string, error) {
+	return parseShortLinkURL(cardURL, "c")
+}
+
+// parseShortLinkURL will extract the shortLink from a trello.com URL whose
+// path starts with /kind/shortLink
+func parseShortLinkURL(rawURL, kind string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Host != "trello.com" && u.Host != "www.trello.com" {
+		return "", fmt.Errorf("URL %q is not a trello.com URL", rawURL)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] != kind || !shortLinkPattern.MatchString(parts[1]) {
+		return "", fmt.Errorf("URL %q is not a trello.com/%s/ URL", rawURL, kind)
+	}
+	return parts[1], nil
+}
+
+// CardByShortLink will return the card with the given shortLink, the 8
+// characters following /c/ in the card URL. Full card URLs are accepted too.
+// https://developers.trello.com/advanced-reference/card#get-1-cards-card-id-or-shortlink
+func (c *Client) CardByShortLink(shortLink string) (*Card, error) {
+	if strings.Contains(shortLink, "/") {
+		var err error
+		if shortLink, err = ParseCardURL(shortLink); err != nil {
+			return nil, err
+		}
+	}
+	if !shortLinkPattern.MatchString(shortLink) {
+		return nil, fmt.Errorf("Card shortLink %q is invalid. It must be 8 letters or digits", shortLink)
+	}
+	return c.Card(shortLink)
+}
+
+// ParseBoardURL will extract the shortLink from a board URL such as
+// https://trello.com/b/iZVEfBeQ/go-trello-test-board
+func ParseBoardURL(boardURL string) (string, error) {
+	return parseShortLinkURL(boardURL, "b")
+}
+
+// BoardByShortLink will return the board with the given shortLink, the 8
+// characters following /b/ in the board URL. Full board URLs are accepted too.
+// https://developers.trello.com/advanced-reference/board#get-1-boards-board-id
+func (c *Client) BoardByShortLink(shortLink string) (*Board, error) {
+	if strings.Contains(shortLink, "/") {
+		var err error
+		if shortLink, err = ParseBoardURL(shortLink); err != nil {
+			return nil, err
+		}
+	}
+	if !shortLinkPattern.MatchString(shortLink) {
+		return nil, fmt.Errorf("Board shortLink %q is invalid. It must be 8 letters or digits", shortLink)
+	}
+	return c.Board(shortLink)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// fullReloadThreshold is the number of changed cards above which a sync
+// reloads all the cards of the board in one request instead of one request
+// per card.
+const fullReloadThreshold = 20
+
+// BoardSync keeps a local copy of the open lists and cards of a board up to
+// date by fetching only the actions since the previous sync, and then only
+// the cards and lists those actions touched. The fields may be persisted and
+// set back to resume syncing later.
+type BoardSync struct {
+	Board *Board
+	Lists map[string]*List
+	Cards map[string]*Card
+	// Since is the id of the last action applied, or a date, e.g.
+	// "2016-02-24T13:45:52.391Z". Only newer actions are applied.
+	Since string
+}
+
+// SyncResult holds what changed during a sync
+type SyncResult struct {
+	Actions      []Action // the actions applied, oldest first
+	Cards        []*Card  // cards created, updated or moved in
+	Lists        []*List  // lists created or updated
+	RemovedCards []string // ids of the cards deleted, archived or moved out
+	RemovedLists []string // ids of the lists archived or moved out
+}
+
+// NewBoardSync will load the open lists and cards of the board, and start
+// syncing from its latest action.
+func NewBoardSync(b *Board) (*BoardSync, error) {
+	latest, err := b.actions(url.Values{"limit": {"1"}})
+	if err != nil {
+		return nil, err
+	}
+	s := &BoardSync{
+		Board: b,
+		Lists: map[string]*List{},
+		Cards: map[string]*Card{},
+	}
+	if len(latest) > 0 {
+		s.Since = latest[0].Id
+	}
+
+	lists, err := b.Lists()
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		s.Lists[lists[i].Id] = &lists[i]
+	}
+	if err = s.reloadCards(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Sync will fetch the actions of the board since the last sync and update the
+// local copy accordingly.
+func (s *BoardSync) Sync() (*SyncResult, error) {
+	if s.Lists == nil {
+		s.Lists = map[string]*List{}
+	}
+	if s.Cards == nil {
+		s.Cards = map[string]*Card{}
+	}
+	actions, err := s.Board.actionsSince(s.Since, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &SyncResult{Actions: actions}
+	if len(actions) == 0 {
+		return result, nil
+	}
+
+	var cardIds, listIds []string
+	touchedCards := map[string]bool{}
+	touchedLists := map[string]bool{}
+	deleted := map[string]bool{}
+	touch := func(ids *[]string, touched map[string]bool, id string) {
+		if id != "" && !touched[id] {
+			touched[id] = true
+			*ids = append(*ids, id)
+		}
+	}
+	for _, action := range actions {
+		if ActionType(action.Type) == ActionDeleteCard {
+			deleted[action.Data.Card.Id] = true
+		}
+		touch(&cardIds, touchedCards, action.Data.Card.Id)
+		touch(&listIds, touchedLists, action.Data.List.Id)
+		touch(&listIds, touchedLists, action.Data.ListBefore.Id)
+		touch(&listIds, touchedLists, action.Data.ListAfter.Id)
+	}
+
+	for _, id := range listIds {
+		list, err := s.Board.client.List(id)
+		if err != nil {
+			if err = s.checkGone(err); err != nil {
+				return nil, err
+			}
+		}
+		if list == nil || list.Closed || list.IdBoard != s.Board.Id {
+			if _, ok := s.Lists[id]; ok {
+				delete(s.Lists, id)
+				result.RemovedLists = append(result.RemovedLists, id)
+			}
+			continue
+		}
+		s.Lists[id] = list
+		result.Lists = append(result.Lists, list)
+	}
+
+	if len(cardIds) > fullReloadThreshold {
+		previous := s.Cards
+		if err = s.reloadCards(); err != nil {
+			return nil, err
+		}
+		for _, id := range cardIds {
+			if card, ok := s.Cards[id]; ok {
+				result.Cards = append(result.Cards, card)
+			} else if _, ok := previous[id]; ok {
+				result.RemovedCards = append(result.RemovedCards, id)
+			}
+		}
+	} else {
+		for _, id := range cardIds {
+			var card *Card
+			if !deleted[id] {
+				if card, err = s.Board.client.Card(id); err != nil {
+					if err = s.checkGone(err); err != nil {
+						return nil, err
+					}
+				}
+			}
+			if card == nil || card.Closed || card.IdBoard != s.Board.Id {
+				if _, ok := s.Cards[id]; ok {
+					delete(s.Cards, id)
+					result.RemovedCards = append(result.RemovedCards, id)
+				}
+				continue
+			}
+			s.Cards[id] = card
+			result.Cards = append(result.Cards, card)
+		}
+	}
+
+	s.Since = actions[len(actions)-1].Id
+	return result, nil
+}
+
+// checkGone will return nil when err means that the object fetched is no
+// longer on the synced board, and err otherwise. Trello answers 404 for
+// deleted objects, and 401 for objects moved to a board the token can't
+// read, which is only told apart from a revoked token by reading the board.
+func (s *BoardSync) checkGone(err error) error {
+	e, ok := err.(*Error)
+	if !ok {
+		return err
+	}
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return nil
+	case http.StatusUnauthorized:
+		var board struct{}
+		return s.Board.client.getJSON("/boards/"+s.Board.Id+"?fields=id", &board)
+	}
+	return err
+}
+
+func (s *BoardSync) reloadCards() error {
+	cards, err := s.Board.Cards()
+	if err != nil {
+		return err
+	}
+	s.Cards = map[string]*Card{}
+	for i := range cards {
+		s.Cards[cards[i].Id] = &cards[i]
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBoardSync(t *testing.T) {
+	routes := map[string]string{
+		// newest first: c3 moved to a board the token can't read, c2
+		// deleted and c1 moved to the list l2
+		"/1/boards/b1/actions": `[
+			{"id":"a3","type":"moveCardFromBoard","data":{"card":{"id":"c3"}}},
+			{"id":"a2","type":"deleteCard","data":{"card":{"id":"c2"},"list":{"id":"l1"}}},
+			{"id":"a1","type":"updateCard","data":{"card":{"id":"c1"},"listBefore":{"id":"l1"},"listAfter":{"id":"l2"}}}
+		]`,
+		"/1/boards/b1": `{"id":"b1"}`,
+		"/1/lists/l1":  `{"id":"l1","name":"To do","idBoard":"b1"}`,
+		"/1/lists/l2":  `{"id":"l2","name":"Done","idBoard":"b1"}`,
+		"/1/card/c1":   `{"id":"c1","name":"Moved","idBoard":"b1","idList":"l2"}`,
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/1/boards/b1/actions":
+			if since := r.URL.Query().Get("since"); since != "a0" {
+				w.Write([]byte("[]"))
+				return
+			}
+		case "/1/card/c3":
+			http.Error(w, "unauthorized card permission requested", http.StatusUnauthorized)
+			return
+		}
+		serveRoutes(t, routes)(w, r)
+	})
+
+	s := &BoardSync{
+		Board: &Board{client: client, Id: "b1"},
+		Lists: map[string]*List{"l1": {Id: "l1", Name: "Todo", IdBoard: "b1"}},
+		Cards: map[string]*Card{
+			"c1": {Id: "c1", IdBoard: "b1", IdList: "l1"},
+			"c2": {Id: "c2", IdBoard: "b1", IdList: "l1"},
+			"c3": {Id: "c3", IdBoard: "b1", IdList: "l1"},
+		},
+		Since: "a0",
+	}
+	result, err := s.Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Actions) != 3 || result.Actions[0].Id != "a1" || result.Actions[2].Id != "a3" {
+		t.Errorf("Sync applied actions %v, want a1, a2 and a3 in order", result.Actions)
+	}
+	if len(result.Cards) != 1 || result.Cards[0].IdList != "l2" {
+		t.Errorf("Sync updated cards %v, want c1 in l2", result.Cards)
+	}
+	if want := []string{"c2", "c3"}; !reflect.DeepEqual(result.RemovedCards, want) {
+		t.Errorf("Sync removed cards %v, want %v", result.RemovedCards, want)
+	}
+	if len(result.Lists) != 2 || s.Lists["l1"].Name != "To do" || s.Lists["l2"] == nil {
+		t.Errorf("Sync updated lists %v, want l1 renamed and l2 added", result.Lists)
+	}
+	if len(s.Cards) != 1 || s.Cards["c1"] == nil {
+		t.Errorf("Sync left cards %v, want only c1", s.Cards)
+	}
+	if s.Since != "a3" {
+		t.Errorf("Sync moved the cursor to %q, want a3", s.Since)
+	}
+
+	result, err = s.Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Actions) != 0 || s.Since != "a3" {
+		t.Errorf("Sync without new actions applied %v and moved the cursor to %q", result.Actions, s.Since)
+	}
+}
+
+func TestBoardSyncRevokedToken(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/1/boards/b1/actions" {
+			w.Write([]byte(`[{"id":"a1","type":"updateCard","data":{"card":{"id":"c1"}}}]`))
+			return
+		}
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+	})
+
+	s := &BoardSync{
+		Board: &Board{client: client, Id: "b1"},
+		Cards: map[string]*Card{"c1": {Id: "c1", IdBoard: "b1"}},
+		Since: "a0",
+	}
+	if _, err := s.Sync(); err == nil {
+		t.Error("Sync succeeded with a revoked token")
+	}
+	if len(s.Cards) != 1 || s.Since != "a0" {
+		t.Errorf("Sync with a revoked token left cards %v and cursor %q, want them unchanged", s.Cards, s.Since)
+	}
+}
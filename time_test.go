@@ -0,0 +1,71 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want time.Time
+	}{
+		{`null`, time.Time{}},
+		{`""`, time.Time{}},
+		{`"2016-02-24T13:45:52.391Z"`, time.Date(2016, 2, 24, 13, 45, 52, 391000000, time.UTC)},
+		{`"2016-02-24T14:45:52+01:00"`, time.Date(2016, 2, 24, 13, 45, 52, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		var got Time
+		if err := json.Unmarshal([]byte(test.json), &got); err != nil {
+			t.Errorf("Unmarshal(%s) failed: %v", test.json, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", test.json, got, test.want)
+		}
+	}
+
+	var got Time
+	if err := json.Unmarshal([]byte(`"24/02/2016"`), &got); err == nil {
+		t.Errorf("Unmarshal of an invalid date succeeded with %v", got)
+	}
+}
+
+func TestTimeMarshalJSON(t *testing.T) {
+	tests := []struct {
+		time Time
+		want string
+	}{
+		{Time{}, `null`},
+		{Time{time.Date(2016, 2, 24, 13, 45, 52, 391000000, time.UTC)}, `"2016-02-24T13:45:52.391Z"`},
+		{Time{time.Date(2016, 2, 24, 14, 45, 52, 0, time.FixedZone("CET", 3600))}, `"2016-02-24T13:45:52.000Z"`},
+	}
+	for _, test := range tests {
+		got, err := json.Marshal(test.time)
+		if err != nil {
+			t.Errorf("Marshal(%v) failed: %v", test.time, err)
+			continue
+		}
+		if string(got) != test.want {
+			t.Errorf("Marshal(%v) = %s, want %s", test.time, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Trello returns at most 1000 actions per request
+const maxActionsLimit = 1000
+
+// WatchEvent is delivered by a Watcher for each new action of the board, with
+// its data decoded by Action.ParseData, or for a failed poll when Err is set.
+type WatchEvent struct {
+	Action *Action
+	Data   interface{}
+	// DataErr is set when Action.ParseData failed, Data is then nil
+	DataErr error
+	// Err is set when a poll failed, Action is then nil
+	Err error
+}
+
+// Watcher polls the actions of a board and delivers the new ones, oldest
+// first. The id of the last action received can be saved and given back as
+// Since to resume watching after a restart without missing or repeating
+// actions.
+type Watcher struct {
+	Board *Board
+	// Interval between two polls, defaults to 10 seconds
+	Interval time.Duration
+	// Types restricts the actions delivered, all of them when empty
+	Types []ActionType
+	// Since is the id of the last action already handled. When empty, only
+	// the actions created after Watch is called are delivered.
+	Since string
+}
+
+// Watch will watch the board for new actions until ctx is done, see Watcher.
+func (b *Board) Watch(ctx context.Context) <-chan WatchEvent {
+	w := &Watcher{Board: b}
+	return w.Watch(ctx)
+}
+
+// Watch will start polling the board until ctx is done. The returned channel
+// is closed once the watcher has stopped.
+func (w *Watcher) Watch(ctx context.Context) <-chan WatchEvent {
+	events := make(chan WatchEvent)
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+
+	go func() {
+		defer close(events)
+
+		send := func(event WatchEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		cursor := w.Since
+		if cursor == "" {
+			// the newest action, whatever its type, marks the starting point
+			latest, err := w.Board.actions(url.Values{"limit": {"1"}})
+			for err != nil {
+				if !send(WatchEvent{Err: err}) || !sleepContext(ctx, interval) {
+					return
+				}
+				latest, err = w.Board.actions(url.Values{"limit": {"1"}})
+			}
+			if len(latest) > 0 {
+				cursor = latest[0].Id
+			}
+		}
+
+		seen := newRecentIds(maxActionsLimit)
+		for {
+			actions, err := w.Board.actionsSince(cursor, w.Types)
+			if err != nil && !send(WatchEvent{Err: err}) {
+				return
+			}
+			for i := range actions {
+				action := &actions[i]
+				if seen.contains(action.Id) {
+					continue
+				}
+				seen.add(action.Id)
+				cursor = action.Id
+
+				event := WatchEvent{Action: action}
+				event.Data, event.DataErr = action.ParseData()
+				if !send(event) {
+					return
+				}
+			}
+			if !sleepContext(ctx, interval) {
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// actionsSince will return all the actions of the board created after the
+// action since, oldest first, following the pages of results as needed.
+func (b *Board) actionsSince(since string, types []ActionType) ([]Action, error) {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(maxActionsLimit))
+	if since != "" {
+		params.Set("since", since)
+	}
+	if len(types) > 0 {
+		params.Set("filter", actionFilter(types))
+	}
+
+	var all []Action
+	for {
+		page, err := b.actions(params)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < maxActionsLimit {
+			break
+		}
+		params.Set("before", page[len(page)-1].Id)
+	}
+
+	// Trello returns the newest actions first
+	for i, j := 0, len(all)-1; i < j; i, j = i+1, j-1 {
+		all[i], all[j] = all[j], all[i]
+	}
+	return all, nil
+}
+
+// sleepContext will wait for d, returning false if ctx is done first.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// recentIds remembers the last ids added to it.
+type recentIds struct {
+	ids  map[string]bool
+	ring []string
+	next int
+}
+
+func newRecentIds(size int) *recentIds {
+	return &recentIds{
+		ids:  map[string]bool{},
+		ring: make([]string, size),
+	}
+}
+
+func (r *recentIds) contains(id string) bool {
+	return r.ids[id]
+}
+
+func (r *recentIds) add(id string) {
+	delete(r.ids, r.ring[r.next])
+	r.ring[r.next] = id
+	r.ids[id] = true
+	r.next = (r.next + 1) % len(r.ring)
+}
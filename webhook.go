@@ -0,0 +1,95 @@
+/*
+Copyright 2014 go-trello authors. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trello
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WebhookPayload is the body Trello posts to the callback URL of a webhook:
+// the action which triggered it and the model the webhook watches.
+// https://developers.trello.com/apis/webhooks
+type WebhookPayload struct {
+	Action Action       `json:"action"`
+	Model  WebhookModel `json:"model"`
+}
+
+// WebhookModel is the board, list or card watched by a webhook. Its Board,
+// List and Card methods decode it as the matching type.
+type WebhookModel struct {
+	client *Client
+	raw    json.RawMessage
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+}
+
+// DecodeWebhook will decode the body of a webhook callback request, e.g.
+//
+//	payload, err := client.DecodeWebhook(r.Body)
+//	if err == nil && trello.ActionType(payload.Action.Type) == trello.ActionCommentCard {
+//		data, _ := payload.Action.ParseData()
+//		fmt.Println(data.(*trello.CommentData).Text)
+//	}
+func (c *Client) DecodeWebhook(r io.Reader) (*WebhookPayload, error) {
+	payload := &WebhookPayload{}
+	if err := json.NewDecoder(r).Decode(payload); err != nil {
+		return nil, err
+	}
+	payload.Action.client = c
+	payload.Model.client = c
+	return payload, nil
+}
+
+func (m *WebhookModel) UnmarshalJSON(b []byte) error {
+	type model WebhookModel
+	if err := json.Unmarshal(b, (*model)(m)); err != nil {
+		return err
+	}
+	m.raw = append(json.RawMessage(nil), b...)
+	return nil
+}
+
+// Board will decode the model of a webhook registered on a board
+func (m *WebhookModel) Board() (*Board, error) {
+	board := &Board{}
+	if err := json.Unmarshal(m.raw, board); err != nil {
+		return nil, err
+	}
+	board.client = m.client
+	return board, nil
+}
+
+// List will decode the model of a webhook registered on a list
+func (m *WebhookModel) List() (*List, error) {
+	list := &List{}
+	if err := json.Unmarshal(m.raw, list); err != nil {
+		return nil, err
+	}
+	list.client = m.client
+	return list, nil
+}
+
+// Card will decode the model of a webhook registered on a card
+func (m *WebhookModel) Card() (*Card, error) {
+	card := &Card{}
+	if err := json.Unmarshal(m.raw, card); err != nil {
+		return nil, err
+	}
+	card.client = m.client
+	return card, nil
+}